@@ -0,0 +1,102 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package business provides business-day calendars, the date adjustment conventions
+// used in financial markets (Following, Modified Following etc), and the generation of
+// coupon and payment schedules between two dates.
+package business
+
+import (
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+// Calendar decides which dates are business days. Weekends and public holidays are
+// typically not business days.
+//
+// A calendar must have at least one business day in every month, otherwise date
+// adjustment will not terminate.
+type Calendar interface {
+	IsBusinessDay(d date.Date) bool
+}
+
+// CalendarFunc is an adapter to allow the use of ordinary functions as a Calendar.
+type CalendarFunc func(d date.Date) bool
+
+// IsBusinessDay returns f(d).
+func (f CalendarFunc) IsBusinessDay(d date.Date) bool {
+	return f(d)
+}
+
+// AllDays is a calendar in which every day is a business day. Adjustment against this
+// calendar never alters any date.
+var AllDays Calendar = CalendarFunc(func(date.Date) bool { return true })
+
+// Weekends is a calendar in which Saturdays and Sundays are not business days.
+var Weekends Calendar = NewHolidayCalendar([]time.Weekday{time.Saturday, time.Sunday})
+
+// HolidayCalendar is a Calendar based on a set of weekend days plus a set of
+// specific holiday dates.
+type HolidayCalendar struct {
+	weekend  [7]bool
+	holidays map[date.Date]struct{}
+}
+
+// NewHolidayCalendar constructs a calendar in which the specified weekdays and the
+// specified holidays are not business days.
+func NewHolidayCalendar(weekend []time.Weekday, holidays ...date.Date) HolidayCalendar {
+	cal := HolidayCalendar{holidays: make(map[date.Date]struct{}, len(holidays))}
+	for _, wd := range weekend {
+		cal.weekend[wd] = true
+	}
+	for _, h := range holidays {
+		cal.holidays[h] = struct{}{}
+	}
+	return cal
+}
+
+// WithHolidays returns a copy of the calendar with additional holidays.
+func (cal HolidayCalendar) WithHolidays(holidays ...date.Date) HolidayCalendar {
+	c2 := HolidayCalendar{weekend: cal.weekend, holidays: make(map[date.Date]struct{}, len(cal.holidays)+len(holidays))}
+	for h := range cal.holidays {
+		c2.holidays[h] = struct{}{}
+	}
+	for _, h := range holidays {
+		c2.holidays[h] = struct{}{}
+	}
+	return c2
+}
+
+// IsHoliday tests whether d is one of the specific holidays. Weekends are not included.
+func (cal HolidayCalendar) IsHoliday(d date.Date) bool {
+	_, exists := cal.holidays[d]
+	return exists
+}
+
+// IsBusinessDay tests whether d is neither a weekend day nor a holiday.
+func (cal HolidayCalendar) IsBusinessDay(d date.Date) bool {
+	if cal.weekend[d.Weekday()] {
+		return false
+	}
+	return !cal.IsHoliday(d)
+}
+
+// AddBusinessDays moves forwards (or backwards if n is negative) by n business days.
+// The starting date need not itself be a business day. If n is zero, d is returned
+// unchanged.
+func AddBusinessDays(d date.Date, n int, cal Calendar) date.Date {
+	step := date.Date(1)
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+	for n > 0 {
+		d += step
+		if cal.IsBusinessDay(d) {
+			n--
+		}
+	}
+	return d
+}
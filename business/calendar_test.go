@@ -0,0 +1,91 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package business
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+var (
+	d0329 = date.New(2024, time.March, 29) // Good Friday
+	d0330 = date.New(2024, time.March, 30) // Saturday
+	d0331 = date.New(2024, time.March, 31) // Sunday
+	d0401 = date.New(2024, time.April, 1)  // Easter Monday
+	d0402 = date.New(2024, time.April, 2)  // Tuesday
+)
+
+var easter = NewHolidayCalendar([]time.Weekday{time.Saturday, time.Sunday}, d0329, d0401)
+
+func TestHolidayCalendar(t *testing.T) {
+	cases := []struct {
+		d           date.Date
+		holiday     bool
+		businessDay bool
+	}{
+		{d0329 - 1, false, true},
+		{d0329, true, false},
+		{d0330, false, false},
+		{d0331, false, false},
+		{d0401, true, false},
+		{d0402, false, true},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s", i, c.d), func(t *testing.T) {
+			if easter.IsHoliday(c.d) != c.holiday {
+				t.Errorf("%d: %s got %v, want %v", i, c.d, easter.IsHoliday(c.d), c.holiday)
+			}
+			if easter.IsBusinessDay(c.d) != c.businessDay {
+				t.Errorf("%d: %s got %v, want %v", i, c.d, easter.IsBusinessDay(c.d), c.businessDay)
+			}
+		})
+	}
+}
+
+func TestWithHolidays(t *testing.T) {
+	extra := date.New(2024, time.April, 3)
+	cal := easter.WithHolidays(extra)
+	if !cal.IsHoliday(extra) || !cal.IsHoliday(d0329) {
+		t.Errorf("expected both holidays in %v", cal)
+	}
+	if easter.IsHoliday(extra) {
+		t.Errorf("original calendar should be unchanged")
+	}
+}
+
+func TestAllDaysAndWeekends(t *testing.T) {
+	if !AllDays.IsBusinessDay(d0330) {
+		t.Errorf("AllDays: %s should be a business day", d0330)
+	}
+	if Weekends.IsBusinessDay(d0330) || Weekends.IsBusinessDay(d0331) || !Weekends.IsBusinessDay(d0401) {
+		t.Errorf("Weekends: wrong result")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cases := []struct {
+		d    date.Date
+		n    int
+		want date.Date
+	}{
+		{d0329 - 1, 0, d0329 - 1},
+		{d0329 - 1, 1, d0402},
+		{d0329 - 1, 2, d0402 + 1},
+		{d0402, -1, d0329 - 1},
+		{d0331, 1, d0402},
+		{d0331, -1, d0329 - 1},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s %d", i, c.d, c.n), func(t *testing.T) {
+			got := AddBusinessDays(c.d, c.n, easter)
+			if got != c.want {
+				t.Errorf("%d: got %s, want %s", i, got, c.want)
+			}
+		})
+	}
+}
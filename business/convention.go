@@ -0,0 +1,99 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package business
+
+import (
+	"fmt"
+
+	"github.com/rickb777/date/v2"
+)
+
+// Convention is a business-day adjustment convention. It specifies how a date that
+// falls on a non-business day is moved to a nearby business day.
+//
+// See https://en.wikipedia.org/wiki/Date_rolling
+type Convention int
+
+const (
+	// Unadjusted leaves dates unchanged, even if they are not business days.
+	Unadjusted Convention = iota
+
+	// Following moves a date to the first business day after it.
+	Following
+
+	// ModifiedFollowing moves a date to the first business day after it, unless that
+	// day is in the next month, in which case the date is moved to the last business
+	// day before it instead.
+	ModifiedFollowing
+
+	// Preceding moves a date to the last business day before it.
+	Preceding
+
+	// ModifiedPreceding moves a date to the last business day before it, unless that
+	// day is in the previous month, in which case the date is moved to the first business
+	// day after it instead.
+	ModifiedPreceding
+
+	// EndOfMonth moves a date to the last business day of its month. This is used
+	// for instruments that always pay at month end.
+	EndOfMonth
+)
+
+var conventionNames = []string{"Unadjusted", "Following", "ModifiedFollowing", "Preceding", "ModifiedPreceding", "EndOfMonth"}
+
+// String returns the name of the convention.
+func (conv Convention) String() string {
+	if conv < 0 || int(conv) >= len(conventionNames) {
+		return fmt.Sprintf("Convention(%d)", int(conv))
+	}
+	return conventionNames[conv]
+}
+
+// Adjust applies the convention to a date using a business-day calendar.
+// Dates that are already business days are unchanged, except for the
+// EndOfMonth convention, which always moves to the end of the month.
+func (conv Convention) Adjust(d date.Date, cal Calendar) date.Date {
+	switch conv {
+	case Following:
+		return following(d, cal)
+
+	case ModifiedFollowing:
+		f := following(d, cal)
+		if f.Month() != d.Month() {
+			return preceding(d, cal)
+		}
+		return f
+
+	case Preceding:
+		return preceding(d, cal)
+
+	case ModifiedPreceding:
+		p := preceding(d, cal)
+		if p.Month() != d.Month() {
+			return following(d, cal)
+		}
+		return p
+
+	case EndOfMonth:
+		last := d + date.Date(d.LastDayOfMonth()-d.Day())
+		return preceding(last, cal)
+	}
+
+	return d
+}
+
+func following(d date.Date, cal Calendar) date.Date {
+	for !cal.IsBusinessDay(d) {
+		d++
+	}
+	return d
+}
+
+func preceding(d date.Date, cal Calendar) date.Date {
+	for !cal.IsBusinessDay(d) {
+		d--
+	}
+	return d
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package business
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+func TestConventionAdjust(t *testing.T) {
+	sat0831 := date.New(2024, time.August, 31)
+	sun0901 := date.New(2024, time.September, 1)
+	fri0830 := date.New(2024, time.August, 30)
+	mon0902 := date.New(2024, time.September, 2)
+	wed0904 := date.New(2024, time.September, 4)
+	fri0927 := date.New(2024, time.September, 27)
+	mon0930 := date.New(2024, time.September, 30)
+
+	cases := []struct {
+		conv Convention
+		d    date.Date
+		want date.Date
+	}{
+		{Unadjusted, sat0831, sat0831},
+		{Following, wed0904, wed0904},
+		{Following, sat0831, mon0902},
+		{ModifiedFollowing, sat0831, fri0830},
+		{ModifiedFollowing, d0330, d0329 - 1},
+		{ModifiedFollowing, d0331 - 7, d0331 - 6},
+		{Preceding, sun0901, fri0830},
+		{ModifiedPreceding, sun0901, mon0902},
+		{ModifiedPreceding, d0331, d0329 - 1},
+		{EndOfMonth, wed0904, mon0930},
+		{EndOfMonth, d0329 - 10, d0329 - 1},
+		{EndOfMonth, fri0927, mon0930},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s %s", i, c.conv, c.d), func(t *testing.T) {
+			got := c.conv.Adjust(c.d, easter)
+			if got != c.want {
+				t.Errorf("%d: %s %s got %s, want %s", i, c.conv, c.d, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConventionString(t *testing.T) {
+	if ModifiedFollowing.String() != "ModifiedFollowing" {
+		t.Errorf("got %s", ModifiedFollowing)
+	}
+	if Convention(99).String() != "Convention(99)" {
+		t.Errorf("got %s", Convention(99))
+	}
+}
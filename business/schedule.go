@@ -0,0 +1,223 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package business
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/gregorian"
+	"github.com/rickb777/date/v2/timespan"
+	"github.com/rickb777/period"
+)
+
+// Stub specifies where an irregular period is placed when the frequency does not divide
+// the schedule exactly.
+type Stub int
+
+const (
+	// NoStub requires the frequency to divide the schedule exactly; otherwise generation fails.
+	NoStub Stub = iota
+
+	// ShortFront places a short irregular period at the start. Dates are rolled backwards
+	// from the termination date.
+	ShortFront
+
+	// LongFront merges the irregular period at the start with the first regular period.
+	LongFront
+
+	// ShortBack places a short irregular period at the end. Dates are rolled forwards
+	// from the effective date.
+	ShortBack
+
+	// LongBack merges the irregular period at the end with the last regular period.
+	LongBack
+)
+
+var stubNames = []string{"NoStub", "ShortFront", "LongFront", "ShortBack", "LongBack"}
+
+// String returns the name of the stub type.
+func (stub Stub) String() string {
+	if stub < 0 || int(stub) >= len(stubNames) {
+		return fmt.Sprintf("Stub(%d)", int(stub))
+	}
+	return stubNames[stub]
+}
+
+// Rule specifies how a Schedule is generated.
+type Rule struct {
+	// Frequency is the regular interval between dates, e.g. P3M for quarterly payments.
+	// Only whole years, months, weeks and days are used; any time component is ignored.
+	Frequency period.Period
+
+	// Stub specifies the position of any irregular period.
+	Stub Stub
+
+	// RollEndOfMonth, when true and the anchor date (the effective date for NoStub and
+	// back stubs, or the termination date for front stubs) is the last day of its month,
+	// causes every unadjusted date to be the last day of its month. This only applies
+	// when the frequency is in years or months.
+	RollEndOfMonth bool
+
+	// Convention adjusts each unadjusted date to a business day.
+	Convention Convention
+
+	// Calendar decides which days are business days. If nil, Weekends is used.
+	Calendar Calendar
+}
+
+// Schedule holds the dates generated from a Rule. Both slices have the same length,
+// which is one more than the number of periods, and start with the effective date and
+// end with the termination date.
+type Schedule struct {
+	Unadjusted []date.Date
+	Adjusted   []date.Date
+}
+
+// Generate produces the schedule of dates from effective to termination. An error is
+// returned if termination is not after effective, if the frequency is negative or has
+// no years, months, weeks or days, or if no stub is allowed but the frequency does not
+// divide the schedule exactly.
+func (rule Rule) Generate(effective, termination date.Date) (Schedule, error) {
+	if termination <= effective {
+		return Schedule{}, fmt.Errorf("business.Generate: termination %s must be after effective %s", termination, effective)
+	}
+
+	if rule.Frequency.IsNegative() {
+		return Schedule{}, fmt.Errorf("business.Generate: frequency %s must not be negative", rule.Frequency)
+	}
+
+	y, m, d := rule.Frequency.Years(), rule.Frequency.Months(), rule.Frequency.DaysIncWeeks()
+	months := 12*y + m
+	if months == 0 && d == 0 {
+		return Schedule{}, fmt.Errorf("business.Generate: frequency %s has no years, months, weeks or days", rule.Frequency)
+	}
+
+	var unadjusted []date.Date
+	var exact bool
+	switch rule.Stub {
+	case NoStub, ShortBack, LongBack:
+		unadjusted, exact = rollForwards(effective, termination, months, d, rule.RollEndOfMonth)
+	case ShortFront, LongFront:
+		unadjusted, exact = rollBackwards(effective, termination, months, d, rule.RollEndOfMonth)
+	default:
+		return Schedule{}, fmt.Errorf("business.Generate: unknown stub %s", rule.Stub)
+	}
+
+	if !exact {
+		n := len(unadjusted)
+		switch rule.Stub {
+		case NoStub:
+			return Schedule{}, fmt.Errorf("business.Generate: frequency %s does not divide %s to %s exactly", rule.Frequency, effective, termination)
+		case LongFront:
+			if n > 2 {
+				unadjusted = append(unadjusted[:1], unadjusted[2:]...)
+			}
+		case LongBack:
+			if n > 2 {
+				unadjusted = append(unadjusted[:n-2], unadjusted[n-1])
+			}
+		}
+	}
+
+	cal := rule.Calendar
+	if cal == nil {
+		cal = Weekends
+	}
+
+	adjusted := make([]date.Date, len(unadjusted))
+	for i, u := range unadjusted {
+		adjusted[i] = rule.Convention.Adjust(u, cal)
+	}
+
+	return Schedule{Unadjusted: unadjusted, Adjusted: adjusted}, nil
+}
+
+// Len returns the number of periods in the schedule.
+func (s Schedule) Len() int {
+	if len(s.Adjusted) == 0 {
+		return 0
+	}
+	return len(s.Adjusted) - 1
+}
+
+// Accruals returns the accrual periods between successive adjusted dates.
+func (s Schedule) Accruals() []timespan.DateRange {
+	return accruals(s.Adjusted)
+}
+
+// UnadjustedAccruals returns the accrual periods between successive unadjusted dates.
+func (s Schedule) UnadjustedAccruals() []timespan.DateRange {
+	return accruals(s.Unadjusted)
+}
+
+func accruals(dates []date.Date) []timespan.DateRange {
+	if len(dates) < 2 {
+		return nil
+	}
+	ranges := make([]timespan.DateRange, len(dates)-1)
+	for i := range ranges {
+		ranges[i] = timespan.BetweenDates(dates[i], dates[i+1])
+	}
+	return ranges
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// rollForwards generates dates from the effective date until the termination date is
+// reached. The flag is true if the termination date falls exactly on a regular date.
+func rollForwards(effective, termination date.Date, months, days int, eom bool) ([]date.Date, bool) {
+	dates := []date.Date{effective}
+	for i := 1; ; i++ {
+		next := roll(effective, i*months, i*days, eom)
+		if next >= termination {
+			return append(dates, termination), next == termination
+		}
+		dates = append(dates, next)
+	}
+}
+
+// rollBackwards generates dates from the termination date until the effective date is
+// reached. The flag is true if the effective date falls exactly on a regular date.
+func rollBackwards(effective, termination date.Date, months, days int, eom bool) ([]date.Date, bool) {
+	var reversed []date.Date
+	exact := false
+	for i := 1; ; i++ {
+		prev := roll(termination, -i*months, -i*days, eom)
+		if prev <= effective {
+			exact = prev == effective
+			break
+		}
+		reversed = append(reversed, prev)
+	}
+
+	dates := make([]date.Date, 0, len(reversed)+2)
+	dates = append(dates, effective)
+	for i := len(reversed) - 1; i >= 0; i-- {
+		dates = append(dates, reversed[i])
+	}
+	return append(dates, termination), exact
+}
+
+// roll adds a number of months and days to an anchor date. Unlike Date.AddDate, the
+// day of the month is clamped to the end of the month instead of overflowing into the
+// next month.
+func roll(anchor date.Date, months, days int, eom bool) date.Date {
+	if months == 0 {
+		return anchor + date.Date(days)
+	}
+
+	year, month, day := anchor.Date()
+	isEnd := day == gregorian.DaysIn(year, month)
+
+	ty, tm, _ := date.New(year, month+time.Month(months), 1).Date()
+	last := gregorian.DaysIn(ty, tm)
+	if day > last || (eom && isEnd) {
+		day = last
+	}
+
+	return date.New(ty, tm, day) + date.Date(days)
+}
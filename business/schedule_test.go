@@ -0,0 +1,159 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package business
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/period"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		rule                   Rule
+		effective, termination string
+		unadjusted, adjusted   string
+	}{
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M")},
+			effective: "2024-01-15", termination: "2025-01-15",
+			unadjusted: "2024-01-15 2024-04-15 2024-07-15 2024-10-15 2025-01-15",
+			adjusted:   "2024-01-15 2024-04-15 2024-07-15 2024-10-15 2025-01-15",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P6M"), Convention: ModifiedFollowing},
+			effective: "2024-03-31", termination: "2025-03-31",
+			unadjusted: "2024-03-31 2024-09-30 2025-03-31",
+			adjusted:   "2024-03-29 2024-09-30 2025-03-31",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P1M"), RollEndOfMonth: true},
+			effective: "2024-02-29", termination: "2024-05-31",
+			unadjusted: "2024-02-29 2024-03-31 2024-04-30 2024-05-31",
+			adjusted:   "2024-02-29 2024-03-31 2024-04-30 2024-05-31",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P1M")},
+			effective: "2024-01-31", termination: "2024-04-30",
+			unadjusted: "2024-01-31 2024-02-29 2024-03-31 2024-04-30",
+			adjusted:   "2024-01-31 2024-02-29 2024-03-31 2024-04-30",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M"), Stub: ShortFront},
+			effective: "2024-02-10", termination: "2024-12-15",
+			unadjusted: "2024-02-10 2024-03-15 2024-06-15 2024-09-15 2024-12-15",
+			adjusted:   "2024-02-10 2024-03-15 2024-06-15 2024-09-15 2024-12-15",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M"), Stub: LongFront, Convention: Following},
+			effective: "2024-02-10", termination: "2024-12-15",
+			unadjusted: "2024-02-10 2024-06-15 2024-09-15 2024-12-15",
+			adjusted:   "2024-02-12 2024-06-17 2024-09-16 2024-12-16",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M"), Stub: ShortBack, Convention: Following},
+			effective: "2024-01-15", termination: "2024-11-20",
+			unadjusted: "2024-01-15 2024-04-15 2024-07-15 2024-10-15 2024-11-20",
+			adjusted:   "2024-01-15 2024-04-15 2024-07-15 2024-10-15 2024-11-20",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M"), Stub: LongBack, Convention: Unadjusted},
+			effective: "2024-01-15", termination: "2024-11-20",
+			unadjusted: "2024-01-15 2024-04-15 2024-07-15 2024-11-20",
+			adjusted:   "2024-01-15 2024-04-15 2024-07-15 2024-11-20",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P2W"), Stub: ShortBack, Calendar: AllDays},
+			effective: "2024-01-06", termination: "2024-02-10",
+			unadjusted: "2024-01-06 2024-01-20 2024-02-03 2024-02-10",
+			adjusted:   "2024-01-06 2024-01-20 2024-02-03 2024-02-10",
+		},
+		{
+			rule:      Rule{Frequency: period.MustParse("P3M"), Stub: LongFront, Convention: Following},
+			effective: "2024-10-01", termination: "2024-12-15",
+			unadjusted: "2024-10-01 2024-12-15",
+			adjusted:   "2024-10-01 2024-12-16",
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s %s", i, c.rule.Frequency, c.rule.Stub), func(t *testing.T) {
+			s, err := c.rule.Generate(date.MustParseISO(c.effective), date.MustParseISO(c.termination))
+			if err != nil {
+				t.Fatalf("%d: %v", i, err)
+			}
+			if got := join(s.Unadjusted); got != c.unadjusted {
+				t.Errorf("%d: unadjusted got %s, want %s", i, got, c.unadjusted)
+			}
+			if got := join(s.Adjusted); got != c.adjusted {
+				t.Errorf("%d: adjusted got %s, want %s", i, got, c.adjusted)
+			}
+			if s.Len() != len(s.Adjusted)-1 {
+				t.Errorf("%d: got %d periods", i, s.Len())
+			}
+		})
+	}
+}
+
+func TestAccruals(t *testing.T) {
+	rule := Rule{Frequency: period.MustParse("P6M"), Convention: ModifiedFollowing}
+	s, err := rule.Generate(date.MustParseISO("2024-03-31"), date.MustParseISO("2025-03-31"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acc := s.Accruals()
+	if len(acc) != 2 {
+		t.Fatalf("got %v", acc)
+	}
+	if acc[0].Start() != date.MustParseISO("2024-03-29") || acc[0].End() != date.MustParseISO("2024-09-30") {
+		t.Errorf("got %v", acc[0])
+	}
+	if acc[1].Start() != acc[0].End() || acc[1].End() != date.MustParseISO("2025-03-31") {
+		t.Errorf("got %v", acc[1])
+	}
+
+	un := s.UnadjustedAccruals()
+	if un[0].Start() != date.MustParseISO("2024-03-31") || un[0].Days() != 183 {
+		t.Errorf("got %v", un[0])
+	}
+
+	if (Schedule{}).Accruals() != nil || (Schedule{}).Len() != 0 {
+		t.Errorf("empty schedule should have no accruals")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	cases := []struct {
+		rule                   Rule
+		effective, termination string
+	}{
+		{Rule{Frequency: period.MustParse("P3M")}, "2024-01-15", "2024-01-15"},
+		{Rule{Frequency: period.MustParse("P3M")}, "2024-01-15", "2024-01-01"},
+		{Rule{Frequency: period.MustParse("-P3M")}, "2024-01-15", "2025-01-15"},
+		{Rule{Frequency: period.MustParse("P3M")}, "2024-01-15", "2024-11-20"},
+		{Rule{Frequency: period.MustParse("P3M"), Stub: Stub(99)}, "2024-01-15", "2024-11-20"},
+		{Rule{Frequency: period.MustParse("PT12H")}, "2024-01-15", "2024-11-20"},
+		{Rule{}, "2024-01-15", "2024-11-20"},
+	}
+
+	for i, c := range cases {
+		_, err := c.rule.Generate(date.MustParseISO(c.effective), date.MustParseISO(c.termination))
+		if err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+}
+
+func join(dates []date.Date) string {
+	s := make([]string, len(dates))
+	for i, d := range dates {
+		s[i] = d.String()
+	}
+	return strings.Join(s, " ")
+}
@@ -154,6 +154,31 @@ func (c Clock) TruncateMillisecond() Clock {
 	return (c / Millisecond) * Millisecond
 }
 
+// Truncate returns the result of rounding c toward zero to a multiple of d, so for
+// positive clock values it is the latest multiple of d that is not after c. For example,
+// 10:20 truncated to 15 minutes is 10:15. If d <= 0, c is returned unchanged.
+func (c Clock) Truncate(d time.Duration) Clock {
+	return Clock(time.Duration(c).Truncate(d))
+}
+
+// Round returns the result of rounding c to the nearest multiple of d, so 10:20 rounded
+// to 15 minutes is 10:15 and 10:23 is 10:30. Halfway values are rounded away from zero.
+// If d <= 0, c is returned unchanged. The result can be 24:00 (see Day).
+func (c Clock) Round(d time.Duration) Clock {
+	return Clock(time.Duration(c).Round(d))
+}
+
+// Ceil returns the result of rounding c up to a multiple of d, i.e. the earliest
+// multiple of d that is not before c. For example, 10:20 rounded up to 15 minutes is
+// 10:30. If d <= 0, c is returned unchanged. The result can be 24:00 (see Day).
+func (c Clock) Ceil(d time.Duration) Clock {
+	t := c.Truncate(d)
+	if t < c {
+		return t + Clock(d)
+	}
+	return t
+}
+
 // Mod24 calculates the remainder vs 24 hours using Euclidean division, in which the result
 // will be less than 24 hours and is never negative. Note that this imposes the assumption that
 // every day has 24 hours (not correct when daylight saving changes in any timezone).
@@ -410,6 +410,19 @@ func TestClockParseGoods(t *testing.T) {
 		{"1:20:30.04pm", New(13, 20, 30, 40)},
 		{"1:20:30.4pm", New(13, 20, 30, 400)},
 		{"1:20:30.pm", New(13, 20, 30, 0)},
+		{"T10", New(10, 0, 0, 0)},
+		{"T10:15", New(10, 15, 0, 0)},
+		{"T101530", New(10, 15, 30, 0)},
+		{"10:15:30,5", New(10, 15, 30, 500)},
+		{"101530,25", New(10, 15, 30, 250)},
+		{"10:15:30.123456789", New(10, 15, 30, 0) + 123456789},
+		{"10.5", New(10, 30, 0, 0)},
+		{"10,25", New(10, 15, 0, 0)},
+		{"T10.1", New(10, 6, 0, 0)},
+		{"10:15.5", New(10, 15, 30, 0)},
+		{"1015,75", New(10, 15, 45, 0)},
+		{"00.000000001", 3600},
+		{"24:00", Day},
 	}
 	for i, x := range cases {
 		t.Run(fmt.Sprintf("%d %s", i, x.str), func(t *testing.T) {
@@ -453,6 +466,19 @@ func TestClockParseBads(t *testing.T) {
 		{"1:02:03-04pm"},
 		{"1:02:03-004pm"},
 		{"1:02:03.0045pm"},
+		{""},
+		{"T"},
+		{"TT10"},
+		{"10."},
+		{"10:15,"},
+		{"10.5.5"},
+		{"10:15:30.1234567891"},
+		{"10:15:30:00"},
+		{"10:1"},
+		{"101"},
+		{"1015301"},
+		{"10:15Z"},
+		{"10:15+02:00"},
 	}
 	for i, x := range cases {
 		t.Run(fmt.Sprintf("%d %s", i, x), func(t *testing.T) {
@@ -465,3 +491,27 @@ func TestClockParseBads(t *testing.T) {
 		})
 	}
 }
+
+func TestClockRounding(t *testing.T) {
+	cases := []struct {
+		c                     Clock
+		d                     time.Duration
+		truncate, round, ceil Clock
+	}{
+		{c: New(10, 20, 0, 0), d: 15 * time.Minute, truncate: New(10, 15, 0, 0), round: New(10, 15, 0, 0), ceil: New(10, 30, 0, 0)},
+		{c: New(10, 23, 0, 0), d: 15 * time.Minute, truncate: New(10, 15, 0, 0), round: New(10, 30, 0, 0), ceil: New(10, 30, 0, 0)},
+		{c: New(10, 22, 30, 0), d: 15 * time.Minute, truncate: New(10, 15, 0, 0), round: New(10, 30, 0, 0), ceil: New(10, 30, 0, 0)},
+		{c: New(10, 15, 0, 0), d: 15 * time.Minute, truncate: New(10, 15, 0, 0), round: New(10, 15, 0, 0), ceil: New(10, 15, 0, 0)},
+		{c: New(23, 50, 0, 0), d: time.Hour, truncate: New(23, 0, 0, 0), round: Day, ceil: Day},
+		{c: New(10, 20, 0, 1), d: time.Second, truncate: New(10, 20, 0, 0), round: New(10, 20, 0, 0), ceil: New(10, 20, 1, 0)},
+		{c: New(10, 20, 0, 0), d: 0, truncate: New(10, 20, 0, 0), round: New(10, 20, 0, 0), ceil: New(10, 20, 0, 0)},
+		{c: New(10, 20, 0, 0), d: -time.Hour, truncate: New(10, 20, 0, 0), round: New(10, 20, 0, 0), ceil: New(10, 20, 0, 0)},
+		{c: New(-1, -30, 0, 0), d: time.Hour, truncate: New(-1, 0, 0, 0), round: New(-2, 0, 0, 0), ceil: New(-1, 0, 0, 0)},
+	}
+
+	for i, c := range cases {
+		if c.c.Truncate(c.d) != c.truncate || c.c.Round(c.d) != c.round || c.c.Ceil(c.d) != c.ceil {
+			t.Errorf("%d: %s %v got %s %s %s", i, c.c, c.d, c.c.Truncate(c.d), c.c.Round(c.d), c.c.Ceil(c.d))
+		}
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Markers holds the words written after 12-hour clock times for times before noon and
+// from noon onwards. The "PM" layout element uses them as they are, whereas "pm" uses
+// them in lower case.
+type Markers struct {
+	AM, PM string
+}
+
+// EnglishMarkers are the default markers, "AM" and "PM".
+var EnglishMarkers = Markers{AM: "AM", PM: "PM"}
+
+// layout element kinds
+const (
+	elemLiteral = iota
+	elemHour
+	elemHour12
+	elemHour12Pad
+	elemMinute
+	elemMinutePad
+	elemSecond
+	elemSecondPad
+	elemPM
+	elemPMLower
+	elemFraction     // fixed number of digits, e.g. ".000"
+	elemFractionTrim // trailing zeros are removed, e.g. ".999"
+)
+
+type layoutElem struct {
+	kind   int
+	text   string // the literal text, or the layout element itself
+	digits int    // for fractions
+}
+
+// splitLayout splits a layout into its elements, using the same reference time as the
+// time package, i.e. 15:04:05.
+func splitLayout(layout string) []layoutElem {
+	var elems []layoutElem
+	literal := 0
+
+	for i := 0; i < len(layout); {
+		kind, n, digits := layoutElemAt(layout, i)
+		if kind == elemLiteral {
+			i++
+			continue
+		}
+
+		if literal < i {
+			elems = append(elems, layoutElem{kind: elemLiteral, text: layout[literal:i]})
+		}
+		elems = append(elems, layoutElem{kind: kind, text: layout[i : i+n], digits: digits})
+		i += n
+		literal = i
+	}
+
+	if literal < len(layout) {
+		elems = append(elems, layoutElem{kind: elemLiteral, text: layout[literal:]})
+	}
+	return elems
+}
+
+// layoutElemAt finds the layout element starting at index i, giving its kind and length.
+func layoutElemAt(layout string, i int) (kind, n, digits int) {
+	rest := layout[i:]
+	switch {
+	case strings.HasPrefix(rest, "15"):
+		return elemHour, 2, 0
+	case strings.HasPrefix(rest, "03"):
+		return elemHour12Pad, 2, 0
+	case strings.HasPrefix(rest, "04"):
+		return elemMinutePad, 2, 0
+	case strings.HasPrefix(rest, "05"):
+		return elemSecondPad, 2, 0
+	case strings.HasPrefix(rest, "PM"):
+		return elemPM, 2, 0
+	case strings.HasPrefix(rest, "pm"):
+		return elemPMLower, 2, 0
+	}
+
+	switch rest[0] {
+	case '3':
+		return elemHour12, 1, 0
+	case '4':
+		return elemMinute, 1, 0
+	case '5':
+		return elemSecond, 1, 0
+	case '.', ',':
+		if len(rest) > 1 && (rest[1] == '0' || rest[1] == '9') {
+			j := 1
+			for j < len(rest) && rest[j] == rest[1] {
+				j++
+			}
+			// a fraction is not followed by a digit and has at most nine digits
+			if (j == len(rest) || !isDigits(rest[j:j+1])) && j <= 10 {
+				if rest[1] == '0' {
+					return elemFraction, j, j - 1
+				}
+				return elemFractionTrim, j, j - 1
+			}
+		}
+	}
+	return elemLiteral, 1, 0
+}
+
+// Format returns a textual representation of the clock value formatted according to a
+// layout, which uses the same reference time as the time package, i.e. 15:04:05. The
+// layout elements are
+//
+//   - "15" for the hour on the 24-hour clock, "03" or "3" for the hour on the 12-hour clock
+//   - "04" or "4" for the minute and "05" or "5" for the second
+//   - "PM" or "pm" for the AM/PM marker
+//   - ".000" or ".999", or with a comma instead of the full stop, for the fraction of a
+//     second; there can be from one to nine digits, giving the precision. The fraction
+//     is truncated, not rounded. With 9s, trailing zeros are removed and there is
+//     nothing at all when the fraction is zero.
+//
+// Everything else in the layout is copied to the result. So time.Kitchen can be used, for
+// example. The value is taken modulo 24 hours (see Mod24), except that the special case of
+// midnight at the end of a day is "24:00" on the 24-hour clock, as per HhMm, and 12 AM on
+// the 12-hour clock, as per HhMm12.
+//
+// The markers are "AM" and "PM"; use FormatWith for other markers.
+func (c Clock) Format(layout string) string {
+	return c.FormatWith(layout, EnglishMarkers)
+}
+
+// FormatWith is as per Format, except that the AM/PM markers are specified, allowing
+// them to be localised.
+func (c Clock) FormatWith(layout string, markers Markers) string {
+	cm := c.Mod24()
+	hour := int(clockHour(cm))
+	if c == Day {
+		hour = 24
+	}
+	h12, _ := clockHour12(cm)
+
+	marker := markers.AM
+	if cm >= Noon {
+		marker = markers.PM
+	}
+
+	buf := &strings.Builder{}
+	for _, e := range splitLayout(layout) {
+		switch e.kind {
+		case elemLiteral:
+			buf.WriteString(e.text)
+		case elemHour:
+			fmt.Fprintf(buf, "%02d", hour)
+		case elemHour12:
+			fmt.Fprintf(buf, "%d", h12)
+		case elemHour12Pad:
+			fmt.Fprintf(buf, "%02d", h12)
+		case elemMinute:
+			fmt.Fprintf(buf, "%d", clockMinute(cm))
+		case elemMinutePad:
+			fmt.Fprintf(buf, "%02d", clockMinute(cm))
+		case elemSecond:
+			fmt.Fprintf(buf, "%d", clockSecond(cm))
+		case elemSecondPad:
+			fmt.Fprintf(buf, "%02d", clockSecond(cm))
+		case elemPM:
+			buf.WriteString(marker)
+		case elemPMLower:
+			buf.WriteString(strings.ToLower(marker))
+		case elemFraction, elemFractionTrim:
+			digits := fmt.Sprintf("%09d", clockNanosecond(cm))[:e.digits]
+			if e.kind == elemFractionTrim {
+				digits = strings.TrimRight(digits, "0")
+				if digits == "" {
+					continue
+				}
+			}
+			buf.WriteByte(e.text[0])
+			buf.WriteString(digits)
+		}
+	}
+	return buf.String()
+}
+
+// MustParseLayout is as per ParseLayout except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseLayout(layout, value string) Clock {
+	c, err := ParseLayout(layout, value)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ParseLayout parses a formatted string and returns the clock value it represents. The
+// layout defines the format as per Format.
+//
+// As with time.Parse, the hour for "15" can have one or two digits, a fraction of a
+// second can follow the seconds even if the layout does not have one, and a ".999"
+// fraction can be of any length up to nine digits. The AM/PM markers are "AM" and "PM"
+// in upper or lower case; use ParseLayoutWith for other markers. The hour can be 24 only
+// when the minutes, seconds and fraction are zero; the result is then Day, the midnight
+// at the end of the day.
+func ParseLayout(layout, value string) (Clock, error) {
+	return ParseLayoutWith(layout, value, EnglishMarkers)
+}
+
+// ParseLayoutWith is as per ParseLayout, except that the AM/PM markers are specified,
+// allowing them to be localised. They are matched without regard to case.
+func ParseLayoutWith(layout, value string, markers Markers) (Clock, error) {
+	c, err := parseLayout(splitLayout(layout), value, markers)
+	if err != nil {
+		return 0, fmt.Errorf("clock.ParseLayout: cannot parse %q as %q: %s", value, layout, err)
+	}
+	return c, nil
+}
+
+func parseLayout(elems []layoutElem, value string, markers Markers) (Clock, error) {
+	var hour, minute, second, nanos int
+	var err error
+	is12, isPM := false, false
+	s := value
+
+	for i, e := range elems {
+		switch e.kind {
+		case elemLiteral:
+			if !strings.HasPrefix(s, e.text) {
+				return 0, fmt.Errorf("expected %q", e.text)
+			}
+			s = s[len(e.text):]
+
+		case elemHour:
+			hour, s, err = parseNumber(s, 1, 2, 24, "hour")
+		case elemHour12, elemHour12Pad:
+			hour, s, err = parseNumber(s, len(e.text), 2, 12, "hour")
+			is12 = true
+			if err == nil && hour == 0 {
+				err = fmt.Errorf("hour out of range")
+			}
+		case elemMinute, elemMinutePad:
+			minute, s, err = parseNumber(s, len(e.text), 2, 59, "minute")
+		case elemSecond, elemSecondPad:
+			second, s, err = parseNumber(s, len(e.text), 2, 59, "second")
+			if err == nil && len(s) > 1 && (s[0] == '.' || s[0] == ',') && isDigits(s[1:2]) &&
+				(i+1 == len(elems) || elems[i+1].kind != elemFraction && elems[i+1].kind != elemFractionTrim) {
+				nanos, s, err = parseFraction(s[1:], 1, 9)
+			}
+
+		case elemPM, elemPMLower:
+			switch {
+			case hasPrefixFold(s, markers.AM):
+				s = s[len(markers.AM):]
+			case hasPrefixFold(s, markers.PM):
+				s = s[len(markers.PM):]
+				isPM = true
+			default:
+				return 0, fmt.Errorf("expected %q or %q", markers.AM, markers.PM)
+			}
+
+		case elemFraction:
+			if s == "" || s[0] != e.text[0] {
+				return 0, fmt.Errorf("expected %q", e.text)
+			}
+			nanos, s, err = parseFraction(s[1:], e.digits, e.digits)
+		case elemFractionTrim:
+			if s != "" && (s[0] == '.' || s[0] == ',') {
+				nanos, s, err = parseFraction(s[1:], 1, 9)
+			}
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if s != "" {
+		return 0, fmt.Errorf("extra text %q", s)
+	}
+
+	if is12 {
+		hour %= 12
+		if isPM {
+			hour += 12
+		}
+	}
+
+	c := Clock(hour)*Hour + Clock(minute)*Minute + Clock(second)*Second + Clock(nanos)
+	if hour == 24 && c != Day {
+		return 0, fmt.Errorf("hour out of range")
+	}
+	return c, nil
+}
+
+// parseNumber parses a number with from lo to hi digits, which must not exceed limit.
+func parseNumber(s string, lo, hi, limit int, name string) (int, string, error) {
+	n := 0
+	for n < hi && n < len(s) && isDigits(s[n:n+1]) {
+		n++
+	}
+	if n < lo {
+		return 0, s, fmt.Errorf("expected %s", name)
+	}
+
+	v, _ := strconv.Atoi(s[:n])
+	if v > limit {
+		return 0, s, fmt.Errorf("%s out of range", name)
+	}
+	return v, s[n:], nil
+}
+
+// parseFraction parses the digits of a fraction of a second to give nanoseconds.
+func parseFraction(s string, lo, hi int) (int, string, error) {
+	n := 0
+	for n < len(s) && isDigits(s[n:n+1]) {
+		n++
+	}
+	if n < lo || n > hi {
+		return 0, s, fmt.Errorf("expected a fraction with %d to %d digits", lo, hi)
+	}
+	return int(fraction(s[:n], Second)), s[n:], nil
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return prefix != "" && len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
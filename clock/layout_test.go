@@ -0,0 +1,116 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockFormatLayout(t *testing.T) {
+	c := New(13, 4, 5, 60)
+	cases := []struct {
+		c      Clock
+		layout string
+		want   string
+	}{
+		{c: c, layout: "15:04:05", want: "13:04:05"},
+		{c: c, layout: "15:04:05.000", want: "13:04:05.060"},
+		{c: c, layout: "15:04:05,000000", want: "13:04:05,060000"},
+		{c: c, layout: "15:04:05.9", want: "13:04:05"},
+		{c: c, layout: "15:04:05.99", want: "13:04:05.06"},
+		{c: c, layout: "15:04:05.999999999", want: "13:04:05.06"},
+		{c: c + 7, layout: "15:04:05.000000000", want: "13:04:05.060000007"},
+		{c: c, layout: "15h04", want: "13h04"},
+		{c: c, layout: "150405", want: "130405"},
+		{c: c, layout: "3:04PM", want: "1:04PM"},
+		{c: c, layout: "03:04:05 pm", want: "01:04:05 pm"},
+		{c: c, layout: "3.4.5", want: "1.4.5"},
+		{c: c, layout: time.Kitchen, want: "1:04PM"},
+		{c: New(0, 30, 0, 0), layout: "3:04pm", want: "12:30am"},
+		{c: Noon, layout: "3:04pm", want: "12:00pm"},
+		{c: Day, layout: "15:04", want: "24:00"},
+		{c: Day, layout: "15:04:05.000", want: Day.String()},
+		{c: Day, layout: "15:04:05", want: Day.HhMmSs()},
+		{c: Day, layout: "3:04pm", want: Day.HhMm12()},
+		{c: Day + Minute, layout: "15:04", want: "00:01"},
+		{c: -Hour, layout: "15:04", want: "23:00"},
+		{c: c, layout: "at 15:04 o'clock", want: "at 13:04 o'clock"},
+	}
+
+	for i, c := range cases {
+		if got := c.c.Format(c.layout); got != c.want {
+			t.Errorf("%d: %s %q got %q, want %q", i, c.c, c.layout, got, c.want)
+		}
+	}
+
+	german := Markers{AM: "vorm.", PM: "nachm."}
+	if got := c.FormatWith("3:04 PM", german); got != "1:04 nachm." {
+		t.Errorf("got %q", got)
+	}
+	if got := c.FormatWith("3:04 pm", Markers{AM: "AM", PM: "PM"}); got != "1:04 pm" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	cases := []struct {
+		layout, value string
+		want          Clock
+	}{
+		{layout: "15:04:05", value: "13:04:05", want: New(13, 4, 5, 0)},
+		{layout: "15:04:05", value: "13:04:05.25", want: New(13, 4, 5, 250)},
+		{layout: "15:04:05.000", value: "13:04:05.060", want: New(13, 4, 5, 60)},
+		{layout: "15:04:05,000", value: "13:04:05,060", want: New(13, 4, 5, 60)},
+		{layout: "15:04:05.999", value: "13:04:05", want: New(13, 4, 5, 0)},
+		{layout: "15:04:05.999", value: "13:04:05.123456789", want: New(13, 4, 5, 123) + 456789},
+		{layout: "150405", value: "130405", want: New(13, 4, 5, 0)},
+		{layout: "15:04", value: "24:00", want: Day},
+		{layout: "15:04", value: "9:30", want: New(9, 30, 0, 0)},
+		{layout: time.Kitchen, value: "1:04PM", want: New(13, 4, 0, 0)},
+		{layout: "3:04pm", value: "12:30am", want: New(0, 30, 0, 0)},
+		{layout: "3:04pm", value: "12:30PM", want: New(12, 30, 0, 0)},
+		{layout: "03:04 PM", value: "09:15 am", want: New(9, 15, 0, 0)},
+		{layout: "3:4:5", value: "9:5:7", want: New(9, 5, 7, 0)},
+		{layout: "3:04", value: "11:30", want: New(11, 30, 0, 0)},
+		{layout: "at 15h04", value: "at 07h45", want: New(7, 45, 0, 0)},
+	}
+
+	for i, c := range cases {
+		got, err := ParseLayout(c.layout, c.value)
+		if err != nil || got != c.want {
+			t.Errorf("%d: %q %q got %s %v, want %s", i, c.layout, c.value, got, err, c.want)
+		}
+	}
+
+	german := Markers{AM: "vorm.", PM: "nachm."}
+	got, err := ParseLayoutWith("3:04 PM", "1:04 Nachm.", german)
+	if err != nil || got != New(13, 4, 0, 0) {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	bads := []struct{ layout, value string }{
+		{"15:04", "13:4"},
+		{"15:04", "25:00"},
+		{"15:04", "24:01"},
+		{"15:04", "13:60"},
+		{"15:04", "13:04 "},
+		{"15:04", "13-04"},
+		{"15:04:05.000", "13:04:05.06"},
+		{"15:04:05.000", "13:04:05"},
+		{"15:04:05.999", "13:04:05.1234567891"},
+		{"3:04pm", "0:30am"},
+		{"3:04pm", "13:30pm"},
+		{"3:04pm", "1:30xm"},
+		{"03:04", "1:30"},
+		{"3:04PM", ""},
+	}
+	for i, c := range bads {
+		_, err := ParseLayout(c.layout, c.value)
+		if err == nil {
+			t.Errorf("%d: %q %q should not parse", i, c.layout, c.value)
+		}
+	}
+}
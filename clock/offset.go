@@ -0,0 +1,222 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OffsetTime is a time of day together with an offset from UTC, such as "10:15:30+02:00".
+// It corresponds to the SQL type TIME WITH TIME ZONE and to the XML Schema type xs:time
+// when that has a time zone.
+//
+// The clock is always at least midnight and before 24:00. Two OffsetTime values are the
+// same time of day if they are equal after conversion to UTC, so use Equal rather than ==.
+// The zero value is midnight UTC.
+type OffsetTime struct {
+	clock  Clock
+	offset int // seconds east of UTC
+}
+
+// NewOffsetTime constructs an OffsetTime from a clock time, which is taken modulo 24 hours
+// (see Mod24), and an offset in seconds east of UTC, as per time.FixedZone.
+func NewOffsetTime(c Clock, offset int) OffsetTime {
+	return OffsetTime{clock: c.Mod24(), offset: offset}
+}
+
+// OffsetTimeAt returns the clock time of t together with the offset of t's location in
+// effect at that time.
+func OffsetTimeAt(t time.Time) OffsetTime {
+	_, offset := t.Zone()
+	return OffsetTime{clock: NewAt(t), offset: offset}
+}
+
+// Clock returns the clock time, which is at least midnight and before 24:00.
+func (ot OffsetTime) Clock() Clock {
+	return ot.clock
+}
+
+// Offset returns the offset in seconds east of UTC.
+func (ot OffsetTime) Offset() int {
+	return ot.offset
+}
+
+// Location returns a fixed time zone with the offset.
+func (ot OffsetTime) Location() *time.Location {
+	if ot.offset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone("", ot.offset)
+}
+
+// UTC returns the same time of day converted to UTC, wrapping around midnight if need be.
+// So "01:00+02:00" becomes "23:00Z".
+func (ot OffsetTime) UTC() OffsetTime {
+	return OffsetTime{clock: (ot.clock - Clock(ot.offset)*Second).Mod24()}
+}
+
+// WithOffset returns the same time of day converted to a different offset, in seconds
+// east of UTC.
+func (ot OffsetTime) WithOffset(offset int) OffsetTime {
+	return OffsetTime{clock: (ot.UTC().clock + Clock(offset)*Second).Mod24(), offset: offset}
+}
+
+// Equal tests whether two OffsetTime values are the same time of day, i.e. they are
+// equal after conversion to UTC. So "10:00+02:00" equals "08:00Z".
+func (ot OffsetTime) Equal(other OffsetTime) bool {
+	return ot.UTC() == other.UTC()
+}
+
+// Compare compares the times of day after conversion to UTC, returning -1, 0 or +1.
+func (ot OffsetTime) Compare(other OffsetTime) int {
+	a, b := ot.UTC().clock, other.UTC().clock
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// String formats the time in ISO 8601 extended format with its offset, e.g.
+// "10:15:30+02:00" or "08:15:30Z" for UTC. Fractions of a second are included only
+// when they are not zero, as per Clock.String.
+func (ot OffsetTime) String() string {
+	buf := &strings.Builder{}
+	if ot.clock%Second != 0 {
+		buf.WriteString(ot.clock.String())
+	} else {
+		buf.WriteString(ot.clock.HhMmSs())
+	}
+	buf.WriteString(formatOffset(ot.offset))
+	return buf.String()
+}
+
+func formatOffset(offset int) string {
+	if offset == 0 {
+		return "Z"
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	if offset%60 != 0 {
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, offset/3600, offset/60%60, offset%60)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset/60%60)
+}
+
+// MustParseOffsetTime is as per ParseOffsetTime except that it panics if the string cannot
+// be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseOffsetTime(s string) OffsetTime {
+	ot, err := ParseOffsetTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return ot
+}
+
+// ParseOffsetTime parses an ISO 8601 time of day with a zone designator, such as
+// "10:15Z", "T101530+0200" or "10:15:30.5-05:00". The time of day is parsed as per Parse,
+// but without the am/pm forms. The zone designator is "Z" for UTC or an offset written
+// as "±hh", "±hhmm" or "±hh:mm". A time of "24:00" is taken to be midnight.
+func ParseOffsetTime(s string) (OffsetTime, error) {
+	hms, zone := s, ""
+	if strings.HasSuffix(s, "Z") || strings.HasSuffix(s, "z") {
+		hms, zone = s[:len(s)-1], "Z"
+	} else if i := strings.LastIndexAny(s, "+-"); i > 0 {
+		hms, zone = s[:i], s[i:]
+	}
+
+	if zone == "" {
+		return OffsetTime{}, fmt.Errorf("clock.ParseOffsetTime: cannot parse %q because there is no zone designator", s)
+	}
+
+	c, err := parseISO(hms)
+	if err != nil {
+		return OffsetTime{}, fmt.Errorf("clock.ParseOffsetTime: cannot parse %q: %w", s, err)
+	}
+
+	offset, ok := parseOffset(zone)
+	if !ok {
+		return OffsetTime{}, fmt.Errorf("clock.ParseOffsetTime: cannot parse %q: invalid zone designator %q", s, zone)
+	}
+
+	return NewOffsetTime(c, offset), nil
+}
+
+// parseOffset parses "Z", "±hh", "±hhmm" or "±hh:mm" to give seconds east of UTC.
+func parseOffset(zone string) (int, bool) {
+	if zone == "Z" {
+		return 0, true
+	}
+
+	sign, hhmm := 1, zone[1:]
+	if zone[0] == '-' {
+		sign = -1
+	}
+
+	// a colon is allowed only between the hours and exactly two digits of minutes
+	if len(hhmm) == 5 && hhmm[2] == ':' {
+		hhmm = hhmm[:2] + hhmm[3:]
+	}
+	if (len(hhmm) != 2 && len(hhmm) != 4) || !isDigits(hhmm) {
+		return 0, false
+	}
+
+	h, _ := strconv.Atoi(hhmm[:2])
+	m := 0
+	if len(hhmm) == 4 {
+		m, _ = strconv.Atoi(hhmm[2:])
+	}
+	if h > 23 || m > 59 {
+		return 0, false
+	}
+	return sign * (h*3600 + m*60), true
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that of String.
+// This also provides JSON encoding as a string.
+func (ot OffsetTime) MarshalText() ([]byte, error) {
+	return []byte(ot.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; see ParseOffsetTime.
+func (ot *OffsetTime) UnmarshalText(data []byte) (err error) {
+	*ot, err = ParseOffsetTime(string(data))
+	return err
+}
+
+// Scan parses some value, which can be a string, a []byte or a time.Time. It implements
+// sql.Scanner, https://golang.org/pkg/database/sql/#Scanner
+func (ot *OffsetTime) Scan(value interface{}) (err error) {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		*ot, err = ParseOffsetTime(string(v))
+	case string:
+		*ot, err = ParseOffsetTime(v)
+	case time.Time:
+		*ot = OffsetTimeAt(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful time with time zone", value, value)
+	}
+	return err
+}
+
+// Value converts the value to a string for storage in a TIME WITH TIME ZONE column. It
+// implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (ot OffsetTime) Value() (driver.Value, error) {
+	return ot.String(), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseOffsetTime(t *testing.T) {
+	cases := []struct {
+		in     string
+		clock  Clock
+		offset int
+		str    string
+		utc    string
+	}{
+		{in: "10:15Z", clock: New(10, 15, 0, 0), str: "10:15:00Z", utc: "10:15:00Z"},
+		{in: "T10:15:30z", clock: New(10, 15, 30, 0), str: "10:15:30Z", utc: "10:15:30Z"},
+		{in: "10:15+02:00", clock: New(10, 15, 0, 0), offset: 7200, str: "10:15:00+02:00", utc: "08:15:00Z"},
+		{in: "101530+0200", clock: New(10, 15, 30, 0), offset: 7200, str: "10:15:30+02:00", utc: "08:15:30Z"},
+		{in: "10:15:30,5+02", clock: New(10, 15, 30, 500), offset: 7200, str: "10:15:30.500+02:00", utc: "08:15:30.500Z"},
+		{in: "01:00+02:00", clock: New(1, 0, 0, 0), offset: 7200, str: "01:00:00+02:00", utc: "23:00:00Z"},
+		{in: "22:30-05:30", clock: New(22, 30, 0, 0), offset: -19800, str: "22:30:00-05:30", utc: "04:00:00Z"},
+		{in: "10.5-01", clock: New(10, 30, 0, 0), offset: -3600, str: "10:30:00-01:00", utc: "11:30:00Z"},
+		{in: "24:00Z", clock: Midnight, str: "00:00:00Z", utc: "00:00:00Z"},
+	}
+
+	for i, c := range cases {
+		ot, err := ParseOffsetTime(c.in)
+		if err != nil || ot.Clock() != c.clock || ot.Offset() != c.offset {
+			t.Errorf("%d: %s got %v %d %v", i, c.in, ot.Clock(), ot.Offset(), err)
+		}
+		if ot.String() != c.str || ot.UTC().String() != c.utc {
+			t.Errorf("%d: %s got %s %s, want %s %s", i, c.in, ot, ot.UTC(), c.str, c.utc)
+		}
+		if !ot.Equal(ot.UTC()) || ot.Compare(ot.UTC()) != 0 {
+			t.Errorf("%d: %s should equal %s", i, ot, ot.UTC())
+		}
+	}
+
+	for i, in := range []string{"", "10:15", "Z", "10:15+2", "10:15+02:0", "10:15+02:", "10:15+0200:", "10:15+:0200", "10:15+020:0", "10:15+24:00", "10:15+02:60", "10:15-02-00", "xx:15Z", "10am+01:00", "10:15+02:00Z"} {
+		_, err := ParseOffsetTime(in)
+		if err == nil {
+			t.Errorf("%d: %q should not parse", i, in)
+		}
+	}
+}
+
+func TestOffsetTimeConversion(t *testing.T) {
+	paris := MustParseOffsetTime("10:00+02:00")
+	tokyo := paris.WithOffset(9 * 3600)
+
+	if tokyo.String() != "17:00:00+09:00" || !tokyo.Equal(paris) || tokyo == paris {
+		t.Errorf("got %s", tokyo)
+	}
+
+	if paris.Compare(MustParseOffsetTime("09:00Z")) != -1 || paris.Compare(MustParseOffsetTime("07:00Z")) != 1 {
+		t.Errorf("unexpected comparison")
+	}
+
+	newYork, _ := time.LoadLocation("America/New_York")
+	at := OffsetTimeAt(time.Date(2024, 7, 1, 9, 30, 0, 0, newYork))
+	if at.String() != "09:30:00-04:00" {
+		t.Errorf("got %s", at)
+	}
+
+	instant := time.Date(2024, 7, 1, 0, 0, 0, 0, at.Location()).Add(at.Clock().DurationSinceMidnight())
+	if !instant.Equal(time.Date(2024, 7, 1, 13, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %s", instant)
+	}
+
+	if (OffsetTime{}).Location() != time.UTC || NewOffsetTime(New(25, 0, 0, 0), 0).Clock() != New(1, 0, 0, 0) {
+		t.Errorf("unexpected zero value or normalisation")
+	}
+}
+
+func TestOffsetTimeJSONAndSQL(t *testing.T) {
+	type Slot struct {
+		At OffsetTime `json:"at"`
+	}
+
+	b, err := json.Marshal(Slot{At: MustParseOffsetTime("10:15+02:00")})
+	if err != nil || string(b) != `{"at":"10:15:00+02:00"}` {
+		t.Errorf("got %s %v", b, err)
+	}
+
+	var s Slot
+	err = json.Unmarshal([]byte(`{"at":"08:15:00.250Z"}`), &s)
+	if err != nil || s.At != NewOffsetTime(New(8, 15, 0, 250), 0) {
+		t.Errorf("got %s %v", s.At, err)
+	}
+
+	want := NewOffsetTime(New(10, 15, 30, 0), 7200)
+	for i, v := range []interface{}{"10:15:30+02", []byte("10:15:30+02:00"), time.Date(0, 1, 1, 10, 15, 30, 0, time.FixedZone("", 7200))} {
+		var ot OffsetTime
+		err := ot.Scan(v)
+		if err != nil || ot != want {
+			t.Errorf("%d: %v got %s %v", i, v, ot, err)
+		}
+	}
+
+	var v driver.Valuer = want
+	got, err := v.Value()
+	if err != nil || got != "10:15:30+02:00" {
+		t.Errorf("got %v %v", got, err)
+	}
+
+	var ot OffsetTime
+	if ot.Scan(nil) != nil || ot.Scan(int64(1)) == nil {
+		t.Errorf("unexpected scan result")
+	}
+}
@@ -6,6 +6,7 @@ package clock
 
 import (
 	"fmt"
+	"math/bits"
 	"runtime"
 	"strconv"
 	"strings"
@@ -24,6 +25,12 @@ func MustParse(hms string) Clock {
 // Parse converts a string representation to a Clock. Acceptable representations
 // are as per ISO-8601 - see https://en.wikipedia.org/wiki/ISO_8601#Times
 //
+// These are the basic and extended formats "hh", "hhmm", "hh:mm", "hhmmss" and
+// "hh:mm:ss", optionally preceded by 'T'. The last field can have a decimal fraction,
+// using either a full stop or a comma as the decimal sign, so "10.5", "10:15.5" and
+// "10:15:30,5" are all allowed. Zone designators, such as "Z" and "+02:00", are not
+// allowed; use ParseOffsetTime for times that have them.
+//
 // Also, conventional AM- and PM-based strings are parsed, such as "2am", "2:45pm".
 // Remember that 12am is midnight and 12pm is noon.
 func Parse(hms string) (clock Clock, err error) {
@@ -36,35 +43,67 @@ func Parse(hms string) (clock Clock, err error) {
 }
 
 func parseISO(hms string) (clock Clock, err error) {
-	switch len(hms) {
-	case 2: // HH
-		return parseClockParts(hms, hms, "", "", "", 0, 0, 0)
+	s := strings.TrimPrefix(hms, "T")
 
-	case 4: // HHMM
-		return parseClockParts(hms, hms[:2], hms[2:], "", "", 0, 0, 0)
+	// the decimal sign may be a full stop or a comma
+	whole, fracs, hasFraction := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	if hasFraction && (fracs == "" || len(fracs) > 9 || !isDigits(fracs)) {
+		return 0, parseError(hms)
+	}
 
-	case 5: // HH:MM
-		if hms[2] != ':' {
+	var fields []string
+	if strings.IndexByte(whole, ':') >= 0 {
+		// extended format hh:mm or hh:mm:ss
+		fields = strings.Split(whole, ":")
+		if len(fields) > 3 {
 			return 0, parseError(hms)
 		}
-		return parseClockParts(hms, hms[:2], hms[3:], "", "", 0, 0, 0)
-
-	case 6: // HHMMSS
-		return parseClockParts(hms, hms[:2], hms[2:4], hms[4:], "", 0, 0, 0)
+	} else {
+		// basic format hh, hhmm or hhmmss
+		for len(whole) > 2 && len(fields) < 2 {
+			fields = append(fields, whole[:2])
+			whole = whole[2:]
+		}
+		fields = append(fields, whole)
+	}
 
-	case 8: // HH:MM:SS
-		if hms[2] != ':' || hms[5] != ':' {
+	units := []Clock{Hour, Minute, Second}
+	for i, f := range fields {
+		if len(f) != 2 || !isDigits(f) {
 			return 0, parseError(hms)
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:], "", 0, 0, 0)
+		n, _ := strconv.Atoi(f)
+		clock += Clock(n) * units[i]
+	}
 
-	case 9, 10, 11, 12, 13, 14, 15, 16, 17, 18: // HH:MM:SS.000...
-		if hms[2] != ':' || hms[5] != ':' || hms[8] != '.' {
-			return 0, parseError(hms)
+	if hasFraction {
+		// the fraction applies to the last field, which may be hours or minutes
+		clock += fraction(fracs, units[len(fields)-1])
+	}
+
+	return clock, nil
+}
+
+// fraction converts the digits of a decimal fraction of some unit to a Clock,
+// truncating to the nearest nanosecond.
+func fraction(digits string, unit Clock) Clock {
+	n, _ := strconv.ParseUint(digits, 10, 64)
+	pow := uint64(1)
+	for range digits {
+		pow *= 10
+	}
+	hi, lo := bits.Mul64(n, uint64(unit))
+	q, _ := bits.Div64(hi, lo, pow)
+	return Clock(q)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || '9' < s[i] {
+			return false
 		}
-		return parseClockParts(hms, hms[:2], hms[3:5], hms[6:8], hms[9:], 9, 0, 0)
 	}
-	return 0, parseError(hms)
+	return true
 }
 
 func parseAmPm(hms string, offset int) (clock Clock, err error) {
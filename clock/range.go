@@ -0,0 +1,234 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Range is a window of time within a day, such as opening hours, quiet hours or a night
+// tariff. The start is included and the end is excluded. If the end is before the start,
+// the range wraps midnight, so "22:00-06:00" runs from 10pm until 6am the next morning.
+//
+// The start is always less than 24 hours. The end is also less than 24 hours, except that
+// it can be exactly 24:00, the midnight at the end of the day. So "00:00-24:00" is the
+// whole day and a range whose start and end are equal is empty.
+//
+// The zero value is an empty range at midnight.
+type Range struct {
+	start, end Clock
+}
+
+// AllDay is the range from midnight to midnight, i.e. "00:00-24:00".
+var AllDay = Range{start: Midnight, end: Day}
+
+// NewRange constructs a range from a start clock (inclusive) to an end clock (exclusive).
+// Both are taken modulo 24 hours (see Mod24), except that an end of exactly 24:00 is kept.
+func NewRange(start, end Clock) Range {
+	if end != Day {
+		end = end.Mod24()
+	}
+	return Range{start: start.Mod24(), end: end}
+}
+
+// Start returns the start clock, which is included in the range.
+func (r Range) Start() Clock {
+	return r.start
+}
+
+// End returns the end clock, which is excluded from the range.
+func (r Range) End() Clock {
+	return r.end
+}
+
+// IsEmpty returns true if the range contains no time.
+func (r Range) IsEmpty() bool {
+	return r.start == r.end
+}
+
+// WrapsMidnight returns true if the range crosses midnight, i.e. its end is before its start.
+func (r Range) WrapsMidnight() bool {
+	return r.end < r.start
+}
+
+// Duration returns the length of the range, assuming the day has 24 hours.
+func (r Range) Duration() time.Duration {
+	if r.WrapsMidnight() {
+		return (Day - r.start + r.end).DurationSinceMidnight()
+	}
+	return (r.end - r.start).DurationSinceMidnight()
+}
+
+// Contains tests whether the range contains a clock time, which is taken modulo 24 hours.
+func (r Range) Contains(c Clock) bool {
+	c = c.Mod24()
+	if r.WrapsMidnight() {
+		return r.start <= c || c < r.end
+	}
+	return r.start <= c && c < r.end
+}
+
+// segments splits the range into at most two parts that do not wrap midnight.
+func (r Range) segments() []Range {
+	switch {
+	case r.IsEmpty():
+		return nil
+	case r.WrapsMidnight():
+		if r.end == Midnight {
+			return []Range{{r.start, Day}}
+		}
+		return []Range{{Midnight, r.end}, {r.start, Day}}
+	}
+	return []Range{r}
+}
+
+// Overlaps tests whether the two ranges have any time in common.
+func (r Range) Overlaps(other Range) bool {
+	return len(r.Intersect(other)) > 0
+}
+
+// Intersect returns the time that is in both ranges. Because either range may wrap
+// midnight, the result has up to two ranges, e.g. "22:00-06:00" and "05:00-23:00" have
+// "05:00-06:00" and "22:00-23:00" in common. The result is empty if the ranges do not
+// overlap.
+func (r Range) Intersect(other Range) []Range {
+	var parts []Range
+	for _, a := range r.segments() {
+		for _, b := range other.segments() {
+			lo, hi := max(a.start, b.start), min(a.end, b.end)
+			if lo < hi {
+				parts = append(parts, Range{lo, hi})
+			}
+		}
+	}
+
+	// rejoin any parts that meet at midnight
+	if n := len(parts); n > 1 && parts[0].start == Midnight && parts[n-1].end == Day {
+		parts[0].start = parts[n-1].start
+		parts = parts[:n-1]
+	}
+	return parts
+}
+
+// String formats the range as two ISO-8601 clock times separated by '-', e.g.
+// "09:00-17:30". Seconds, and then fractions of a second, are included only if either
+// clock time has them.
+func (r Range) String() string {
+	switch {
+	case r.start%Second != 0 || r.end%Second != 0:
+		return r.start.String() + "-" + r.end.String()
+	case r.start%Minute != 0 || r.end%Minute != 0:
+		return rangeHhMmSs(r.start) + "-" + rangeHhMmSs(r.end)
+	}
+	return rangeHhMm(r.start) + "-" + rangeHhMm(r.end)
+}
+
+// String12 formats the range as two 12-hour clock times separated by '-', e.g.
+// "9am-5:30pm". Remember that 12am is midnight and 12pm is noon. An end of 24:00 is
+// also written as 12am, which ParseRange reads as the end of the day, so "00:00-24:00"
+// is "12am-12am". So is the empty range at midnight, which therefore does not survive
+// being written in 12-hour form.
+func (r Range) String12() string {
+	return rangeHh12(r.start) + "-" + rangeHh12(r.end)
+}
+
+func rangeHhMm(c Clock) string {
+	if c == Day {
+		return "24:00"
+	}
+	return c.HhMm()
+}
+
+func rangeHhMmSs(c Clock) string {
+	if c == Day {
+		return "24:00:00"
+	}
+	return c.HhMmSs()
+}
+
+func rangeHh12(c Clock) string {
+	switch {
+	case c%Minute != 0:
+		return c.HhMmSs12()
+	case c%Hour != 0:
+		return c.HhMm12()
+	}
+	return c.Hh12()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that of String.
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; see ParseRange.
+func (r *Range) UnmarshalText(data []byte) (err error) {
+	*r, err = ParseRange(string(data))
+	return err
+}
+
+// MustParseRange is as per ParseRange except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// rangeSeparators are the separators accepted between the start and end of a range.
+var rangeSeparators = []string{"-", "–", "—", " to "}
+
+// ParseRange parses a range written as two clock times separated by a hyphen, an en dash,
+// an em dash or the word "to", e.g. "09:00-17:30", "22:00-06:00", "9am–5pm" or
+// "9:30am to 5pm". Spaces around the clock times are ignored. Each clock time is parsed
+// as per Parse, except that the hour may also be written with a single digit, as in
+// "9:00". An end of "24:00", or "12am" in 12-hour form, is midnight at the end of the
+// day, so "10pm-12am" is the same as "22:00-24:00".
+func ParseRange(s string) (Range, error) {
+	for _, sep := range rangeSeparators {
+		first, second, found := strings.Cut(s, sep)
+		if !found {
+			continue
+		}
+
+		start, err := parseRangeClock(first)
+		if err != nil {
+			return Range{}, fmt.Errorf("clock.ParseRange: cannot parse %q: %w", s, err)
+		}
+
+		end, err := parseRangeClock(second)
+		if err != nil {
+			return Range{}, fmt.Errorf("clock.ParseRange: cannot parse %q: %w", s, err)
+		}
+
+		if start.Mod24() != start || (end.Mod24() != end && end != Day) {
+			return Range{}, fmt.Errorf("clock.ParseRange: cannot parse %q: clock times must be before 24:00", s)
+		}
+
+		// as per String12, an end of 12am is the midnight at the end of the day
+		if end == Midnight && strings.HasSuffix(strings.ToLower(strings.TrimSpace(second)), "am") {
+			end = Day
+		}
+
+		return NewRange(start, end), nil
+	}
+
+	return Range{}, fmt.Errorf("clock.ParseRange: cannot parse %q because there is no separator '-'", s)
+}
+
+func parseRangeClock(s string) (Clock, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 1 || (len(s) > 1 && s[1] == ':') {
+		lower := strings.ToLower(s)
+		if !strings.HasSuffix(lower, "am") && !strings.HasSuffix(lower, "pm") {
+			s = "0" + s
+		}
+	}
+	return Parse(s)
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewRange(t *testing.T) {
+	cases := []struct {
+		r          Range
+		str, str12 string
+		duration   time.Duration
+		wraps      bool
+		empty      bool
+	}{
+		{r: NewRange(New(9, 0, 0, 0), New(17, 30, 0, 0)), str: "09:00-17:30", str12: "9am-5:30pm", duration: 510 * time.Minute},
+		{r: NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0)), str: "22:00-06:00", str12: "10pm-6am", duration: 8 * time.Hour, wraps: true},
+		{r: NewRange(New(22, 0, 0, 0), Midnight), str: "22:00-00:00", str12: "10pm-12am", duration: 2 * time.Hour, wraps: true},
+		{r: NewRange(New(22, 0, 0, 0), Day), str: "22:00-24:00", str12: "10pm-12am", duration: 2 * time.Hour},
+		{r: AllDay, str: "00:00-24:00", str12: "12am-12am", duration: 24 * time.Hour},
+		{r: NewRange(New(9, 0, 0, 0), New(9, 0, 0, 0)+Day), str: "09:00-09:00", str12: "9am-9am", empty: true},
+		{r: NewRange(New(-1, 0, 0, 0), New(25, 0, 0, 0)), str: "23:00-01:00", str12: "11pm-1am", duration: 2 * time.Hour, wraps: true},
+		{r: NewRange(New(8, 0, 15, 0), New(12, 0, 0, 0)), str: "08:00:15-12:00:00", str12: "8:00:15am-12pm", duration: 4*time.Hour - 15*time.Second},
+		{r: Range{}, str: "00:00-00:00", str12: "12am-12am", empty: true},
+	}
+
+	for i, c := range cases {
+		if c.r.String() != c.str || c.r.String12() != c.str12 {
+			t.Errorf("%d: got %s %s, want %s %s", i, c.r, c.r.String12(), c.str, c.str12)
+		}
+		if c.r.Duration() != c.duration || c.r.WrapsMidnight() != c.wraps || c.r.IsEmpty() != c.empty {
+			t.Errorf("%d: %s got %v %v %v", i, c.r, c.r.Duration(), c.r.WrapsMidnight(), c.r.IsEmpty())
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	office := MustParseRange("09:00-17:30")
+	night := MustParseRange("22:00-06:00")
+
+	cases := []struct {
+		c             Clock
+		office, night bool
+	}{
+		{c: Midnight, night: true},
+		{c: New(5, 59, 59, 999), night: true},
+		{c: New(6, 0, 0, 0)},
+		{c: New(9, 0, 0, 0), office: true},
+		{c: New(17, 29, 0, 0), office: true},
+		{c: New(17, 30, 0, 0)},
+		{c: New(22, 0, 0, 0), night: true},
+		{c: Day, night: true},
+		{c: New(33, 0, 0, 0), office: true},
+	}
+
+	for i, c := range cases {
+		if office.Contains(c.c) != c.office || night.Contains(c.c) != c.night {
+			t.Errorf("%d: %s got %v %v", i, c.c, office.Contains(c.c), night.Contains(c.c))
+		}
+	}
+
+	if AllDay.Contains(Midnight) != true || (Range{}).Contains(Midnight) != false {
+		t.Errorf("unexpected result for all-day or empty range")
+	}
+}
+
+func TestRangeIntersect(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want string
+	}{
+		{a: "09:00-17:00", b: "12:00-20:00", want: "[12:00-17:00]"},
+		{a: "09:00-12:00", b: "12:00-20:00", want: "[]"},
+		{a: "22:00-06:00", b: "05:00-23:00", want: "[05:00-06:00 22:00-23:00]"},
+		{a: "22:00-06:00", b: "23:00-02:00", want: "[23:00-02:00]"},
+		{a: "22:00-06:00", b: "20:00-04:00", want: "[22:00-04:00]"},
+		{a: "22:00-06:00", b: "07:00-21:00", want: "[]"},
+		{a: "22:00-06:00", b: "00:00-24:00", want: "[22:00-06:00]"},
+		{a: "22:00-00:00", b: "23:00-24:00", want: "[23:00-24:00]"},
+		{a: "00:00-24:00", b: "00:00-24:00", want: "[00:00-24:00]"},
+		{a: "09:00-09:00", b: "00:00-24:00", want: "[]"},
+	}
+
+	for i, c := range cases {
+		a, b := MustParseRange(c.a), MustParseRange(c.b)
+		ab, ba := fmt.Sprint(a.Intersect(b)), fmt.Sprint(b.Intersect(a))
+		if ab != c.want || ba != c.want {
+			t.Errorf("%d: %s %s got %s %s, want %s", i, a, b, ab, ba, c.want)
+		}
+		if a.Overlaps(b) != (c.want != "[]") {
+			t.Errorf("%d: %s %s overlaps got %v", i, a, b, a.Overlaps(b))
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{in: "09:00-17:30", want: "09:00-17:30"},
+		{in: "9:00 - 17:30", want: "09:00-17:30"},
+		{in: "0900-1730", want: "09:00-17:30"},
+		{in: "9-17", want: "09:00-17:00"},
+		{in: "9am–5pm", want: "09:00-17:00"},
+		{in: "9:30am — 5pm", want: "09:30-17:00"},
+		{in: "9:30AM to 12PM", want: "09:30-12:00"},
+		{in: "10pm-6am", want: "22:00-06:00"},
+		{in: "10pm-12am", want: "22:00-24:00"},
+		{in: "12am-12AM", want: "00:00-24:00"},
+		{in: "12am-6am", want: "00:00-06:00"},
+		{in: "22:00-24:00", want: "22:00-24:00"},
+		{in: "00:00-24:00", want: "00:00-24:00"},
+		{in: "08:00:15-12:00:00", want: "08:00:15-12:00:00"},
+		{in: "08:00:15-12:00:00.500", want: "08:00:15.000-12:00:00.500"},
+	}
+
+	for i, c := range cases {
+		r, err := ParseRange(c.in)
+		if err != nil || r.String() != c.want {
+			t.Errorf("%d: %s got %s %v, want %s", i, c.in, r, err, c.want)
+		}
+	}
+
+	for i, in := range []string{"", "09:00", "09:00/17:00", "xx:00-17:00", "09:00-25:00", "24:00-06:00", "09:00-"} {
+		_, err := ParseRange(in)
+		if err == nil {
+			t.Errorf("%d: %q should not parse", i, in)
+		}
+	}
+}
+
+func TestRangeString12RoundTrip(t *testing.T) {
+	for i, r := range []Range{
+		NewRange(New(22, 0, 0, 0), Day),
+		AllDay,
+		NewRange(New(9, 0, 0, 0), New(17, 30, 0, 0)),
+		NewRange(New(22, 0, 0, 0), New(6, 0, 0, 0)),
+		NewRange(Midnight, New(6, 0, 0, 0)),
+	} {
+		got, err := ParseRange(r.String12())
+		if err != nil || got != r {
+			t.Errorf("%d: %s got %s %v", i, r.String12(), got, err)
+		}
+	}
+}
+
+func TestRangeJSON(t *testing.T) {
+	type Shop struct {
+		Hours Range `json:"hours"`
+	}
+
+	b, err := json.Marshal(Shop{Hours: MustParseRange("22:00-06:00")})
+	if err != nil || string(b) != `{"hours":"22:00-06:00"}` {
+		t.Errorf("got %s %v", b, err)
+	}
+
+	var s Shop
+	err = json.Unmarshal([]byte(`{"hours":"9am-5pm"}`), &s)
+	if err != nil || s.Hours != NewRange(New(9, 0, 0, 0), New(17, 0, 0, 0)) {
+		t.Errorf("got %v %v", s.Hours, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import "time"
+
+// Slots describes how to divide a window of time, such as opening hours, into appointment
+// slots. For example, 30-minute appointments starting on any quarter hour, with 10 minutes
+// between appointments and none over lunch, are
+//
+//	clock.Slots{
+//		Step:    15 * time.Minute,
+//		Length:  30 * time.Minute,
+//		Buffer:  10 * time.Minute,
+//		Exclude: []clock.Range{clock.MustParseRange("12:30-13:30")},
+//	}
+//
+// The slots are worked out assuming every day has 24 hours; combine them with a date
+// using date.Date.Time or date.NewLocalDateTime to obtain the actual times. None of the
+// durations may be negative; if any is, or if Length is 24 hours or more, there are no
+// slots.
+type Slots struct {
+	// Step is the distance between the times at which slots may start, measured from
+	// the start of the window. If it is zero, it is Length plus Buffer.
+	Step time.Duration
+
+	// Length is the length of each slot. If it is zero, it is Step. It must be less
+	// than 24 hours.
+	Length time.Duration
+
+	// Buffer is the free time needed after each slot before the next one can start.
+	Buffer time.Duration
+
+	// Exclude holds windows, such as lunch breaks, that the slots must not overlap.
+	Exclude []Range
+}
+
+// Starts returns the start times of the slots from a clock time (inclusive) to another
+// (exclusive); every slot ends no later than to. The clock times may be 24 hours or more,
+// for windows that continue past midnight, in which case so may the results.
+func (s Slots) Starts(from, to Clock) []Clock {
+	var starts []Clock
+	for _, r := range s.generate(from, to) {
+		starts = append(starts, r[0])
+	}
+	return starts
+}
+
+// Ranges returns the slots from a clock time (inclusive) to another (exclusive); every
+// slot ends no later than to. The clock times may be 24 hours or more, for windows that
+// continue past midnight, in which case the ranges wrap midnight or are taken modulo
+// 24 hours, as per NewRange.
+func (s Slots) Ranges(from, to Clock) []Range {
+	var ranges []Range
+	for _, r := range s.generate(from, to) {
+		ranges = append(ranges, NewRange(r[0], r[1]))
+	}
+	return ranges
+}
+
+// generate finds the start and end of each slot.
+func (s Slots) generate(from, to Clock) [][2]Clock {
+	if s.Step < 0 || s.Length < 0 || s.Buffer < 0 {
+		return nil
+	}
+
+	step, length := Clock(s.Step), Clock(s.Length)
+	if step == 0 {
+		step = length + Clock(s.Buffer)
+	}
+	if length == 0 {
+		length = step
+	}
+	if step == 0 || length >= Day {
+		return nil
+	}
+
+	var slots [][2]Clock
+	for start := from; start+length <= to; {
+		end := start + length
+		if s.excluded(start, end) {
+			start += step
+			continue
+		}
+
+		slots = append(slots, [2]Clock{start, end})
+
+		// the next slot starts at the first step after this one and its buffer
+		next := end + Clock(s.Buffer)
+		start = from + (next - from).Ceil(time.Duration(step))
+	}
+	return slots
+}
+
+func (s Slots) excluded(start, end Clock) bool {
+	slot := NewRange(start, end)
+	for _, x := range s.Exclude {
+		if slot.Overlaps(x) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSlots(t *testing.T) {
+	lunch := MustParseRange("12:30-13:30")
+
+	cases := []struct {
+		slots    Slots
+		from, to Clock
+		want     string
+	}{
+		{
+			slots: Slots{Step: time.Hour},
+			from:  New(9, 0, 0, 0), to: New(12, 0, 0, 0),
+			want: "[09:00-10:00 10:00-11:00 11:00-12:00]",
+		},
+		{
+			slots: Slots{Length: 45 * time.Minute, Buffer: 15 * time.Minute},
+			from:  New(9, 0, 0, 0), to: New(12, 0, 0, 0),
+			want: "[09:00-09:45 10:00-10:45 11:00-11:45]",
+		},
+		{
+			slots: Slots{Step: 15 * time.Minute, Length: 30 * time.Minute, Buffer: 10 * time.Minute, Exclude: []Range{lunch}},
+			from:  New(11, 0, 0, 0), to: New(14, 30, 0, 0),
+			want: "[11:00-11:30 11:45-12:15 13:30-14:00]",
+		},
+		{
+			slots: Slots{Step: 15 * time.Minute, Length: 30 * time.Minute, Exclude: []Range{lunch}},
+			from:  New(11, 50, 0, 0), to: New(14, 10, 0, 0),
+			want: "[11:50-12:20 13:35-14:05]",
+		},
+		{
+			slots: Slots{Step: 30 * time.Minute},
+			from:  New(22, 0, 0, 0), to: New(25, 0, 0, 0),
+			want: "[22:00-22:30 22:30-23:00 23:00-23:30 23:30-24:00 00:00-00:30 00:30-01:00]",
+		},
+		{
+			slots: Slots{Step: time.Hour},
+			from:  New(9, 0, 0, 0), to: New(9, 30, 0, 0),
+			want: "[]",
+		},
+		{
+			slots: Slots{},
+			from:  New(9, 0, 0, 0), to: New(17, 0, 0, 0),
+			want: "[]",
+		},
+		{
+			slots: Slots{Step: 15 * time.Minute, Length: 15 * time.Minute, Buffer: -time.Hour},
+			from:  New(9, 0, 0, 0), to: New(10, 0, 0, 0),
+			want: "[]",
+		},
+		{
+			slots: Slots{Step: 15 * time.Minute, Length: -15 * time.Minute},
+			from:  New(9, 0, 0, 0), to: New(10, 0, 0, 0),
+			want: "[]",
+		},
+		{
+			slots: Slots{Step: -15 * time.Minute, Length: 15 * time.Minute},
+			from:  New(9, 0, 0, 0), to: New(10, 0, 0, 0),
+			want: "[]",
+		},
+	}
+
+	for i, c := range cases {
+		got := fmt.Sprint(c.slots.Ranges(c.from, c.to))
+		if got != c.want {
+			t.Errorf("%d: got %s, want %s", i, got, c.want)
+		}
+	}
+}
+
+func TestSlotStarts(t *testing.T) {
+	slots := Slots{Step: 30 * time.Minute, Exclude: []Range{MustParseRange("23:00-01:00")}}
+	got := fmt.Sprint(slots.Starts(New(22, 0, 0, 0), New(26, 0, 0, 0)))
+	want := fmt.Sprint([]Clock{New(22, 0, 0, 0), New(22, 30, 0, 0), New(25, 0, 0, 0), New(25, 30, 0, 0)})
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// combined with rounding to slot boundaries
+	first := New(9, 7, 0, 0).Ceil(15 * time.Minute)
+	got = fmt.Sprint(Slots{Step: 15 * time.Minute}.Starts(first, New(10, 0, 0, 0)))
+	if got != "[09:15:00.000 09:30:00.000 09:45:00.000]" {
+		t.Errorf("got %s", got)
+	}
+}
@@ -6,6 +6,7 @@ package date
 
 import (
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/rickb777/date/v2/clock"
@@ -77,23 +78,81 @@ func NewAt(t time.Time) Date {
 	return encode(t)
 }
 
+// nowFunc holds the source of the current time; nil means time.Now.
+var nowFunc atomic.Pointer[func() time.Time]
+
+// SetNowFunc sets the source of the current time used by Today, TodayUTC, TodayIn and
+// TodayWithCutoff, and so by everything that depends on today's date. A nil function
+// restores the default, time.Now. It returns a function that restores the previous source.
+//
+// Tests can use this to control the date; see the datetest package. It is safe for
+// concurrent use, but there is only one source for the whole program, so tests that run
+// in parallel will all see the same source.
+func SetNowFunc(fn func() time.Time) (restore func()) {
+	var next *func() time.Time
+	if fn != nil {
+		next = &fn
+	}
+	previous := nowFunc.Swap(next)
+	return func() {
+		nowFunc.Store(previous)
+	}
+}
+
+// now gets the current time from the source set by SetNowFunc.
+func now() time.Time {
+	if fn := nowFunc.Load(); fn != nil {
+		return (*fn)()
+	}
+	return time.Now()
+}
+
 // Today returns today's date according to the current local time.
 func Today() Date {
-	return encode(time.Now())
+	return encode(now().Local())
 }
 
 // TodayUTC returns today's date according to the current UTC time.
 func TodayUTC() Date {
-	return encode(time.Now().UTC())
+	return encode(now().UTC())
 }
 
 // TodayIn returns today's date according to the current time relative to
 // the specified location.
 func TodayIn(loc *time.Location) Date {
-	t := time.Now().In(loc)
+	t := now().In(loc)
 	return encode(t)
 }
 
+// TodayWithCutoff returns today's business date in the specified location, where the date
+// rolls over at a cutoff time rather than at midnight; see NewAtCutoff.
+func TodayWithCutoff(loc *time.Location, cutoff clock.Clock) Date {
+	return NewAtCutoff(now(), loc, cutoff)
+}
+
+// NewAtCutoff returns the business date of an instant in a location, where the date rolls
+// over at a cutoff time rather than at midnight. Times from the cutoff onwards belong to the
+// next date. For example, with a cutoff of 17:00 in New York, 16:59 on Monday is Monday's
+// business date but 17:00 is Tuesday's.
+//
+// So each business date runs from the cutoff on the previous date until the cutoff on its
+// own date. A cutoff of 24:00 (see clock.Day) gives the usual dates, as per TodayIn. A cutoff
+// after 24:00 carries into the next day, so for a business date that starts at 6am on its
+// date and runs until 6am the next morning, the cutoff is 30:00.
+//
+// The cutoff is the wall-clock time in the location, resolved as per Time on days when the
+// clocks change, so a business date may be 23 or 25 hours long.
+func NewAtCutoff(t time.Time, loc *time.Location, cutoff clock.Clock) Date {
+	d := NewAt(t.In(loc))
+	for !t.Before(d.Time(cutoff, loc)) {
+		d++
+	}
+	for t.Before((d - 1).Time(cutoff, loc)) {
+		d--
+	}
+	return d
+}
+
 // Min returns the smallest representable date, which is nearly 6 million years in the past.
 func Min() Date {
 	return Date(math.MinInt32 + 1)
@@ -119,17 +178,64 @@ func (d Date) Midnight() time.Time {
 // MidnightIn returns a Time value corresponding to midnight on the given date d,
 // relative to the specified time zone.  Note that midnight is the beginning
 // of the day rather than the end.
+//
+// In a few time zones, midnight is skipped on the day the clocks go forward; the result
+// is then moved forward by the length of the gap, as per Time. See also StartOfDayIn.
 func (d Date) MidnightIn(loc *time.Location) time.Time {
 	return d.Time(0, loc)
 }
 
+// StartOfDayIn returns the first instant of the given date d in the specified time
+// zone. This is midnight except in the few time zones where the clocks go forward at
+// midnight, such as America/Santiago, for which it is the time the clocks go forward to.
+func (d Date) StartOfDayIn(loc *time.Location) time.Time {
+	t, err := resolve(d, 0, loc, Resolution{Gap: GapReject})
+	if err != nil {
+		// midnight is in a gap, so the day starts when the clocks go forward
+		t, _ = resolve(d, 0, loc, Resolution{})
+		t, _ = t.ZoneBounds()
+	}
+	return t
+}
+
 // Time returns a Time value corresponding to a clock time on the given date d,
 // relative to the specified time zone. A common use-case is to obtain the midnight
-// time, for which the clock value is simply zero.
+// time, for which the clock value is simply zero. Clock values outside the range
+// 0 to 24 hours carry into adjacent days.
+//
+// On days when the clocks change, a clock time that does not exist is moved forward
+// by the length of the gap and one that exists twice gives the earlier instant.
+// Use TimeIn to choose a different resolution.
+//
+// Before TimeIn was added, the result for a nonexistent clock time was whatever
+// time.Date gave, which depends on the time zone; for example, 02:30 on 2024-03-10
+// in New York was 01:30 EST but is now 03:30 EDT.
 func (d Date) Time(clock clock.Clock, loc *time.Location) time.Time {
-	t := decode(d).In(loc)
-	_, offset := t.Zone()
-	return t.Add(time.Duration(-offset) * time.Second).Add(time.Duration(clock))
+	t, _ := resolve(d, clock, loc, Resolution{})
+	return t
+}
+
+// TimeIn returns the instant at which a clock time occurs on the given date d in the
+// specified time zone. Clock values outside the range 0 to 24 hours carry into
+// adjacent days.
+//
+// On days when the clocks change, some clock times do not exist and others exist
+// twice; the resolution decides the result in these cases, possibly an error wrapping
+// ErrNonExistent or ErrAmbiguous.
+func (d Date) TimeIn(clock clock.Clock, loc *time.Location, res Resolution) (time.Time, error) {
+	return resolve(d, clock, loc, res)
+}
+
+// ConvertZone converts a clock time on the given date d in one time zone to the date
+// and clock time at the same instant in another time zone. For example, 09:00 on
+// 2024-03-31 in London is 19:00 on 2024-03-31 in Sydney, and 23:30 in London is 10:30
+// the next day in Sydney.
+//
+// The clock time is resolved in the source time zone as per Time. Use
+// LocalDateTime.Convert to choose a different resolution.
+func (d Date) ConvertZone(c clock.Clock, from, to *time.Location) (Date, clock.Clock) {
+	t := d.Time(c, from).In(to)
+	return NewAt(t), clock.NewAt(t)
 }
 
 // Date returns the year, month, and day of d.
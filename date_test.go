@@ -5,6 +5,7 @@
 package date
 
 import (
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"testing"
@@ -77,6 +78,35 @@ func TestDate_Today(t *testing.T) {
 	}
 }
 
+func TestDate_Today_SetNowFunc(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	fixed := time.Date(2024, 3, 31, 23, 30, 0, 0, time.UTC)
+
+	restore := SetNowFunc(func() time.Time { return fixed })
+
+	if TodayUTC() != New(2024, 3, 31) || TodayIn(tokyo) != New(2024, 4, 1) || Today() != NewAt(fixed.Local()) {
+		t.Errorf("got %s %s %s", TodayUTC(), TodayIn(tokyo), Today())
+	}
+	if TodayWithCutoff(time.UTC, clock.New(23, 0, 0, 0)) != New(2024, 4, 1) {
+		t.Errorf("got %s", TodayWithCutoff(time.UTC, clock.New(23, 0, 0, 0)))
+	}
+
+	// a nil function is time.Now, and restoring it goes back to the fixed time
+	restoreFixed := SetNowFunc(nil)
+	if TodayUTC() != NewAt(time.Now().UTC()) {
+		t.Errorf("got %s", TodayUTC())
+	}
+	restoreFixed()
+	if TodayUTC() != New(2024, 3, 31) {
+		t.Errorf("got %s", TodayUTC())
+	}
+
+	restore()
+	if TodayUTC() != NewAt(time.Now().UTC()) {
+		t.Errorf("got %s", TodayUTC())
+	}
+}
+
 func TestDate_Time(t *testing.T) {
 	cases := []struct {
 		d Date
@@ -153,6 +183,177 @@ func TestDate_Time(t *testing.T) {
 	}
 }
 
+func TestDate_Time_transitions(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	santiago, _ := time.LoadLocation("America/Santiago")
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+
+	cases := []struct {
+		d    Date
+		c    clock.Clock
+		loc  *time.Location
+		want string
+	}{
+		{d: New(2024, time.March, 31), c: clock.New(3, 30, 0, 0), loc: london, want: "2024-03-31T03:30:00+01:00"},
+		{d: New(2024, time.March, 31), c: clock.New(1, 30, 0, 0), loc: london, want: "2024-03-31T02:30:00+01:00"},
+		{d: New(2024, time.October, 27), c: clock.New(1, 30, 0, 0), loc: london, want: "2024-10-27T01:30:00+01:00"},
+		{d: New(2024, time.October, 27), c: clock.New(23, 0, 0, 0), loc: london, want: "2024-10-27T23:00:00Z"},
+		{d: New(2024, time.September, 8), c: clock.New(12, 0, 0, 0), loc: santiago, want: "2024-09-08T12:00:00-03:00"},
+		{d: New(2024, time.September, 8), c: clock.Midnight, loc: santiago, want: "2024-09-08T01:00:00-03:00"},
+		{d: New(2018, time.November, 4), c: clock.New(0, 30, 0, 0), loc: saoPaulo, want: "2018-11-04T01:30:00-02:00"},
+	}
+
+	for i, c := range cases {
+		got := c.d.Time(c.c, c.loc)
+		if got.Format(time.RFC3339) != c.want {
+			t.Errorf("%d: %s %s got %s, want %s", i, c.d, c.c, got.Format(time.RFC3339), c.want)
+		}
+	}
+}
+
+func TestDate_Time_gapChanged(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	d, c := New(2024, time.March, 10), clock.New(2, 30, 0, 0)
+
+	// previously, Time gave the result of time.Date, which moves back to 01:30 EST here;
+	// now the nonexistent 02:30 is shifted forward by the gap to 03:30 EDT
+	old := time.Date(2024, time.March, 10, 2, 30, 0, 0, newYork)
+	if old.Format(time.RFC3339) != "2024-03-10T01:30:00-05:00" {
+		t.Errorf("time.Date got %s", old.Format(time.RFC3339))
+	}
+
+	got := d.Time(c, newYork)
+	if got.Format(time.RFC3339) != "2024-03-10T03:30:00-04:00" {
+		t.Errorf("got %s", got.Format(time.RFC3339))
+	}
+}
+
+func TestDate_TimeIn(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	spring, autumn := New(2024, time.March, 31), New(2024, time.October, 27)
+
+	got, err := spring.TimeIn(clock.New(1, 30, 0, 0), london, Resolution{Gap: GapShiftBackward})
+	if err != nil || got.Format(time.RFC3339) != "2024-03-31T00:30:00Z" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	_, err = spring.TimeIn(clock.New(1, 30, 0, 0), london, Strict)
+	if !errors.Is(err, ErrNonExistent) {
+		t.Errorf("got %v", err)
+	}
+
+	got, err = autumn.TimeIn(clock.New(1, 30, 0, 0), london, Resolution{Overlap: OverlapLater})
+	if err != nil || got.Format(time.RFC3339) != "2024-10-27T01:30:00Z" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	_, err = autumn.TimeIn(clock.New(1, 30, 0, 0), london, Strict)
+	if !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestDate_StartOfDayIn(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	santiago, _ := time.LoadLocation("America/Santiago")
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+
+	cases := []struct {
+		d    Date
+		loc  *time.Location
+		want string
+	}{
+		{d: New(2024, time.March, 31), loc: london, want: "2024-03-31T00:00:00Z"},
+		{d: New(2024, time.April, 1), loc: london, want: "2024-04-01T00:00:00+01:00"},
+		{d: New(2024, time.September, 8), loc: santiago, want: "2024-09-08T01:00:00-03:00"},
+		{d: New(2024, time.April, 7), loc: santiago, want: "2024-04-07T00:00:00-04:00"},
+		{d: New(2018, time.November, 4), loc: saoPaulo, want: "2018-11-04T01:00:00-02:00"},
+	}
+
+	for i, c := range cases {
+		got := c.d.StartOfDayIn(c.loc)
+		if got.Format(time.RFC3339) != c.want || got.Location() != c.loc {
+			t.Errorf("%d: %s got %s, want %s", i, c.d, got.Format(time.RFC3339), c.want)
+		}
+	}
+}
+
+func TestDate_ConvertZone(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	sydney, _ := time.LoadLocation("Australia/Sydney")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	cases := []struct {
+		d        Date
+		c        clock.Clock
+		from, to *time.Location
+		want     string
+	}{
+		{d: New(2024, time.March, 31), c: clock.New(9, 0, 0, 0), from: london, to: sydney, want: "2024-03-31 19:00:00"},
+		{d: New(2024, time.March, 30), c: clock.New(9, 0, 0, 0), from: london, to: sydney, want: "2024-03-30 20:00:00"},
+		{d: New(2024, time.March, 31), c: clock.New(23, 30, 0, 0), from: london, to: sydney, want: "2024-04-01 09:30:00"},
+		{d: New(2024, time.April, 1), c: clock.New(8, 0, 0, 0), from: sydney, to: london, want: "2024-03-31 22:00:00"},
+		{d: New(2024, time.March, 10), c: clock.Noon, from: newYork, to: london, want: "2024-03-10 16:00:00"},
+		{d: New(2024, time.March, 10), c: clock.New(2, 30, 0, 0), from: newYork, to: time.UTC, want: "2024-03-10 07:30:00"},
+		{d: New(2024, time.March, 10), c: clock.New(-1, 0, 0, 0), from: time.UTC, to: time.UTC, want: "2024-03-09 23:00:00"},
+	}
+
+	for i, c := range cases {
+		d, cl := c.d.ConvertZone(c.c, c.from, c.to)
+		if got := d.String() + " " + cl.HhMmSs(); got != c.want {
+			t.Errorf("%d: %s %s got %s, want %s", i, c.d, c.c, got, c.want)
+		}
+	}
+}
+
+func TestNewAtCutoff(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	fivePM := clock.New(17, 0, 0, 0)
+
+	cases := []struct {
+		t      time.Time
+		cutoff clock.Clock
+		want   Date
+	}{
+		{t: time.Date(2024, 3, 11, 16, 59, 59, 0, newYork), cutoff: fivePM, want: New(2024, 3, 11)},
+		{t: time.Date(2024, 3, 11, 17, 0, 0, 0, newYork), cutoff: fivePM, want: New(2024, 3, 12)},
+		{t: time.Date(2024, 3, 11, 23, 59, 0, 0, newYork), cutoff: fivePM, want: New(2024, 3, 12)},
+		{t: time.Date(2024, 3, 12, 0, 0, 0, 0, newYork), cutoff: fivePM, want: New(2024, 3, 12)},
+		// the same instants given in another location
+		{t: time.Date(2024, 3, 11, 20, 59, 0, 0, time.UTC), cutoff: fivePM, want: New(2024, 3, 11)},
+		{t: time.Date(2024, 3, 11, 21, 0, 0, 0, london), cutoff: fivePM, want: New(2024, 3, 12)},
+		// New York clocks go forward at 2am on 10th March 2024
+		{t: time.Date(2024, 3, 9, 17, 0, 0, 0, newYork), cutoff: fivePM, want: New(2024, 3, 10)},
+		{t: time.Date(2024, 3, 10, 16, 0, 0, 0, newYork), cutoff: fivePM, want: New(2024, 3, 10)},
+		{t: time.Date(2024, 3, 10, 3, 15, 0, 0, newYork), cutoff: clock.New(2, 30, 0, 0), want: New(2024, 3, 10)},
+		{t: time.Date(2024, 3, 10, 3, 30, 0, 0, newYork), cutoff: clock.New(2, 30, 0, 0), want: New(2024, 3, 11)},
+		// other cutoffs
+		{t: time.Date(2024, 3, 11, 23, 59, 0, 0, newYork), cutoff: clock.Day, want: New(2024, 3, 11)},
+		{t: time.Date(2024, 3, 12, 5, 59, 0, 0, newYork), cutoff: clock.New(30, 0, 0, 0), want: New(2024, 3, 11)},
+		{t: time.Date(2024, 3, 12, 6, 0, 0, 0, newYork), cutoff: clock.New(30, 0, 0, 0), want: New(2024, 3, 12)},
+		{t: time.Date(2024, 3, 11, 0, 0, 0, 0, newYork), cutoff: clock.Midnight, want: New(2024, 3, 12)},
+	}
+
+	for i, c := range cases {
+		if got := NewAtCutoff(c.t, newYork, c.cutoff); got != c.want {
+			t.Errorf("%d: %s %s got %s, want %s", i, c.t, c.cutoff, got, c.want)
+		}
+	}
+}
+
+func TestTodayWithCutoff(t *testing.T) {
+	for _, z := range []int{-10, -5, 0, 1, 8, 12} {
+		location := time.FixedZone("zone", z*60*60)
+		if TodayWithCutoff(location, clock.Day) != TodayIn(location) {
+			t.Errorf("TodayWithCutoff(%v) == %v, want %v", z, TodayWithCutoff(location, clock.Day), TodayIn(location))
+		}
+		if TodayWithCutoff(location, clock.Midnight) != TodayIn(location)+1 {
+			t.Errorf("TodayWithCutoff(%v) == %v, want %v", z, TodayWithCutoff(location, clock.Midnight), TodayIn(location)+1)
+		}
+	}
+}
+
 func TestDate_LastDayOfMonth(t *testing.T) {
 	cases := []struct {
 		d   Date
@@ -0,0 +1,81 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package datetest provides a controllable source of the current time for testing code
+// that depends on today's date, such as date.Today and view.VDate.IsToday.
+//
+//	func TestSomething(t *testing.T) {
+//		fake := datetest.NewFake(time.Date(2024, 3, 31, 23, 59, 0, 0, time.UTC))
+//		fake.Install(t)
+//		...
+//		fake.Advance(time.Minute)
+//		...
+//	}
+package datetest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+// Fake is a source of the current time that only changes when told to. It is safe for
+// concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a fake time source frozen at a given time. The location of the time is
+// the location of the times returned by Now.
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+// Now returns the fake current time. Its signature is the same as time.Now.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set sets the fake current time.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the fake current time on by a duration, which may be negative, and
+// returns the new time.
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	return f.now
+}
+
+// AdvanceDays moves the fake current time on by a number of days, which may be negative,
+// keeping the same wall-clock time, and returns the new time.
+func (f *Fake) AdvanceDays(days int) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.AddDate(0, 0, days)
+	return f.now
+}
+
+// Install makes the fake the source of the current time for the date package (see
+// date.SetNowFunc) until the end of the test, when the previous source is restored. It
+// also returns a function that restores the previous source sooner.
+//
+// This is safe for concurrent use, but the source is shared by the whole program, so
+// tests that run in parallel all see whichever fake was installed last.
+func (f *Fake) Install(tb testing.TB) (restore func()) {
+	tb.Helper()
+	restore = date.SetNowFunc(f.Now)
+	tb.Cleanup(restore)
+	return restore
+}
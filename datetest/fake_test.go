@@ -0,0 +1,125 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datetest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 3, 31, 23, 59, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	if !fake.Now().Equal(start) || !fake.Now().Equal(start) {
+		t.Errorf("got %s", fake.Now())
+	}
+
+	if got := fake.Advance(time.Minute); !got.Equal(start.Add(time.Minute)) || !fake.Now().Equal(got) {
+		t.Errorf("got %s", got)
+	}
+
+	if got := fake.AdvanceDays(-2); !got.Equal(time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %s", got)
+	}
+
+	fake.Set(start)
+	if !fake.Now().Equal(start) {
+		t.Errorf("got %s", fake.Now())
+	}
+}
+
+func TestFakeInstall(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	fake := NewFake(time.Date(2024, 3, 31, 23, 59, 0, 0, time.UTC))
+
+	t.Run("installed", func(t *testing.T) {
+		fake.Install(t)
+
+		if date.TodayUTC() != date.New(2024, 3, 31) || date.TodayIn(newYork) != date.New(2024, 3, 31) {
+			t.Errorf("got %s %s", date.TodayUTC(), date.TodayIn(newYork))
+		}
+		if date.TodayWithCutoff(newYork, clock.New(17, 0, 0, 0)) != date.New(2024, 4, 1) {
+			t.Errorf("got %s", date.TodayWithCutoff(newYork, clock.New(17, 0, 0, 0)))
+		}
+		if date.Today() != date.NewAt(fake.Now().Local()) {
+			t.Errorf("got %s", date.Today())
+		}
+
+		fake.Advance(time.Minute)
+		if date.TodayUTC() != date.New(2024, 4, 1) || date.TodayIn(newYork) != date.New(2024, 3, 31) {
+			t.Errorf("got %s %s", date.TodayUTC(), date.TodayIn(newYork))
+		}
+	})
+
+	// the real time source is restored after the test
+	if date.TodayUTC() != date.NewAt(time.Now().UTC()) {
+		t.Errorf("got %s", date.TodayUTC())
+	}
+}
+
+func TestFakeConcurrency(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fake.Advance(time.Second)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fake.Now()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !fake.Now().Equal(start.Add(1000 * time.Second)) {
+		t.Errorf("got %s", fake.Now())
+	}
+}
+
+func TestInstallWhileInUse(t *testing.T) {
+	fake := NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				date.TodayUTC()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			restore := date.SetNowFunc(fake.Now)
+			restore()
+		}()
+	}
+	wg.Wait()
+
+	// the restores may have happened in any order, so go back to the real time source
+	date.SetNowFunc(nil)
+
+	restore := fake.Install(t)
+	if date.TodayUTC() != date.New(2024, 1, 1) {
+		t.Errorf("got %s", date.TodayUTC())
+	}
+	restore()
+	if date.TodayUTC() != date.NewAt(time.Now().UTC()) {
+		t.Errorf("got %s", date.TodayUTC())
+	}
+}
@@ -7,6 +7,10 @@
 // and convenient for calendrical calculations and date parsing and formatting
 // (including years outside the [0,9999] interval).
 //
+// LocalDateTime combines a Date with a clock.Clock to give a date and time without a
+// time zone, which can be converted to an instant in any location with explicit handling
+// of daylight-saving transitions.
+//
 // Subpackages provide:
 //
 // * `clock.Clock` which expresses a wall-clock style hours-minutes-seconds with millisecond precision.
@@ -17,6 +21,18 @@
 //
 // * `view.VDate` which wraps `Date` for use in templates etc.
 //
+// * `business` which provides business-day calendars, date adjustment conventions and payment schedules.
+//
+// * `rrule` which expands RFC5545 recurrence rules into dates and time spans.
+//
+// * `ics` which reads and writes iCalendar files containing all-day and timed events.
+//
+// * `series.Series` which holds one value per day over a range of dates, with fill policies and resampling.
+//
+// * `openinghours` which evaluates weekly opening-hours schedules written in OpenStreetMap syntax.
+//
+// * `datetest` which provides a controllable time source for testing code that depends on today's date.
+//
 // # Credits
 //
 // This package follows very closely the design of package time
@@ -8,6 +8,4 @@ require (
 
 require github.com/rickb777/plural v1.4.2 // indirect
 
-go 1.21
-
-toolchain go1.21.4
+go 1.23
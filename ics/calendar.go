@@ -0,0 +1,98 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ics reads and writes iCalendar (RFC5545) files containing events.
+//
+// All-day events (DTSTART;VALUE=DATE) map to timespan.DateRange; the exclusive DTEND
+// of an all-day event matches the half-open model of DateRange exactly. Timed events
+// map to timespan.TimeSpan, using TZID parameters and VTIMEZONE components to
+// identify time zones.
+//
+// Properties and components that this package does not interpret are kept so that
+// they are written out again unchanged.
+//
+// See https://www.rfc-editor.org/rfc/rfc5545
+package ics
+
+import (
+	"strings"
+
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// DefaultProductID is written as the PRODID of calendars that do not have one.
+var DefaultProductID = "-//rickb777//date//EN"
+
+// Component is a generic iCalendar component, such as VALARM or VTODO.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []Component
+}
+
+// Calendar is an iCalendar object (VCALENDAR).
+type Calendar struct {
+	// Properties holds the calendar properties, such as VERSION, PRODID and METHOD.
+	Properties []Property
+
+	Events []Event
+
+	// Components holds components other than VEVENT and VTIMEZONE, e.g. VTODO.
+	// VTIMEZONE components are consumed when reading and regenerated when writing.
+	Components []Component
+}
+
+// Event is an event (VEVENT). It is either an all-day event, which has a date range,
+// or a timed event, which has a time span.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+
+	// AllDay is true for events whose DTSTART is a date; Dates is then used.
+	// Otherwise Span is used.
+	AllDay bool
+	Dates  timespan.DateRange
+	Span   timespan.TimeSpan
+
+	// Properties holds all the other properties, e.g. DTSTAMP, RRULE and
+	// X-properties, in their original order.
+	Properties []Property
+
+	// Components holds the nested components, e.g. VALARM.
+	Components []Component
+}
+
+// NewAllDayEvent constructs an all-day event.
+func NewAllDayEvent(uid, summary string, dates timespan.DateRange) Event {
+	return Event{UID: uid, Summary: summary, AllDay: true, Dates: dates}
+}
+
+// NewTimedEvent constructs a timed event. The time zone is that of the span's
+// start time.
+func NewTimedEvent(uid, summary string, span timespan.TimeSpan) Event {
+	return Event{UID: uid, Summary: summary, Span: span.Normalise()}
+}
+
+// Property gets the first of the other properties with a given name, which is
+// case-insensitive. The flag is false if there is no such property.
+func (e Event) Property(name string) (Property, bool) {
+	return findProperty(e.Properties, name)
+}
+
+// Property gets the first calendar property with a given name, which is
+// case-insensitive. The flag is false if there is no such property.
+func (cal Calendar) Property(name string) (Property, bool) {
+	return findProperty(cal.Properties, name)
+}
+
+func findProperty(props []Property, name string) (Property, bool) {
+	for _, p := range props {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
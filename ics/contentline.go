@@ -0,0 +1,227 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the maximum length of a content line, excluding the line break.
+const maxLineOctets = 75
+
+// Param is a property parameter such as TZID=Europe/London or VALUE=DATE.
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property is a single content line, i.e. a name, some parameters and a value.
+// The value is held verbatim; it is not unescaped.
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param gets the value of a named parameter. The name is case-insensitive.
+// The flag is false if the parameter is absent.
+func (p Property) Param(name string) (string, bool) {
+	for _, pp := range p.Params {
+		if strings.EqualFold(pp.Name, name) {
+			return pp.Value, true
+		}
+	}
+	return "", false
+}
+
+// String formats the property as an unfolded content line.
+func (p Property) String() string {
+	buf := &strings.Builder{}
+	buf.WriteString(p.Name)
+	for _, pp := range p.Params {
+		buf.WriteByte(';')
+		buf.WriteString(pp.Name)
+		buf.WriteByte('=')
+		if strings.ContainsAny(pp.Value, ":;,") {
+			buf.WriteByte('"')
+			buf.WriteString(pp.Value)
+			buf.WriteByte('"')
+		} else {
+			buf.WriteString(pp.Value)
+		}
+	}
+	buf.WriteByte(':')
+	buf.WriteString(p.Value)
+	return buf.String()
+}
+
+// parseProperty parses an unfolded content line. Double-quoted parameter values may
+// contain ':', ';' and ','.
+func parseProperty(line string) (Property, error) {
+	var p Property
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return Property{}, fmt.Errorf("invalid content line %q", line)
+	}
+	p.Name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return Property{}, fmt.Errorf("invalid parameter in %q", line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		j := i + 1 + eq + 1
+
+		var value string
+		if j < len(line) && line[j] == '"' {
+			end := strings.IndexByte(line[j+1:], '"')
+			if end < 0 {
+				return Property{}, fmt.Errorf("unterminated quote in %q", line)
+			}
+			value = line[j+1 : j+1+end]
+			j += end + 2
+		} else {
+			end := strings.IndexAny(line[j:], ";:")
+			if end < 0 {
+				return Property{}, fmt.Errorf("missing ':' in %q", line)
+			}
+			value = line[j : j+end]
+			j += end
+		}
+
+		if j >= len(line) {
+			return Property{}, fmt.Errorf("missing ':' in %q", line)
+		}
+
+		p.Params = append(p.Params, Param{Name: name, Value: value})
+		i = j
+	}
+
+	if line[i] != ':' {
+		return Property{}, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	p.Value = line[i+1:]
+	return p, nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// unfolder reads logical content lines, joining folded physical lines.
+type unfolder struct {
+	scanner *bufio.Scanner
+	pending string
+	hasNext bool
+}
+
+func newUnfolder(r io.Reader) *unfolder {
+	u := &unfolder{scanner: bufio.NewScanner(r)}
+	u.scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	u.advance()
+	return u
+}
+
+func (u *unfolder) advance() {
+	u.hasNext = u.scanner.Scan()
+	if u.hasNext {
+		u.pending = strings.TrimRight(u.scanner.Text(), "\r")
+	}
+}
+
+// next returns the next logical line. Blank lines are skipped.
+func (u *unfolder) next() (string, bool) {
+	for u.hasNext {
+		line := u.pending
+		u.advance()
+		for u.hasNext && len(u.pending) > 0 && (u.pending[0] == ' ' || u.pending[0] == '\t') {
+			line += u.pending[1:]
+			u.advance()
+		}
+		if line != "" {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+func (u *unfolder) err() error {
+	return u.scanner.Err()
+}
+
+// foldingWriter writes content lines, folding them at 75 octets without splitting
+// UTF-8 sequences, and terminating each with CRLF.
+type foldingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (fw *foldingWriter) writeLine(line string) {
+	if fw.err != nil {
+		return
+	}
+
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fw.write(line[:cut])
+		fw.write("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // allow for the leading space
+	}
+	fw.write(line)
+	fw.write("\r\n")
+}
+
+func (fw *foldingWriter) write(s string) {
+	if fw.err != nil {
+		return
+	}
+	n, err := io.WriteString(fw.w, s)
+	fw.n += int64(n)
+	fw.err = err
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// EscapeText escapes a TEXT value: backslash, semicolon, comma and newline.
+func EscapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// UnescapeText reverses EscapeText. Both "\n" and "\N" become a newline.
+func UnescapeText(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	buf := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				buf.WriteByte('\n')
+			default:
+				buf.WriteByte(s[i])
+			}
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
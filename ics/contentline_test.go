@@ -0,0 +1,163 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseProperty(t *testing.T) {
+	cases := []struct {
+		in  string
+		exp Property
+	}{
+		{in: "SUMMARY:Hello", exp: Property{Name: "SUMMARY", Value: "Hello"}},
+		{in: "summary:a:b", exp: Property{Name: "SUMMARY", Value: "a:b"}},
+		{in: "DTSTART;VALUE=DATE:20240101", exp: Property{Name: "DTSTART", Params: []Param{{"VALUE", "DATE"}}, Value: "20240101"}},
+		{in: "DTSTART;tzid=Europe/London;VALUE=DATE-TIME:20240101T090000",
+			exp: Property{Name: "DTSTART", Params: []Param{{"TZID", "Europe/London"}, {"VALUE", "DATE-TIME"}}, Value: "20240101T090000"}},
+		{in: `ATTENDEE;CN="Doe, John; Jr":mailto:john@example.com`,
+			exp: Property{Name: "ATTENDEE", Params: []Param{{"CN", "Doe, John; Jr"}}, Value: "mailto:john@example.com"}},
+		{in: "X-EMPTY:", exp: Property{Name: "X-EMPTY"}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s", i, c.in), func(t *testing.T) {
+			p, err := parseProperty(c.in)
+			if err != nil {
+				t.Fatalf("%d: %v", i, err)
+			}
+			if fmt.Sprint(p) != fmt.Sprint(c.exp) {
+				t.Errorf("%d: got %#v, want %#v", i, p, c.exp)
+			}
+		})
+	}
+}
+
+func TestParsePropertyErrors(t *testing.T) {
+	cases := []string{
+		"SUMMARY",
+		":value",
+		"DTSTART;VALUE",
+		"DTSTART;VALUE=DATE",
+		`ATTENDEE;CN="Doe:x`,
+		`ATTENDEE;CN="Doe"`,
+	}
+
+	for i, c := range cases {
+		if _, err := parseProperty(c); err == nil {
+			t.Errorf("%d: expected an error for %q", i, c)
+		}
+	}
+}
+
+func TestPropertyString(t *testing.T) {
+	cases := []struct {
+		in  Property
+		exp string
+	}{
+		{in: Property{Name: "SUMMARY", Value: "Hello"}, exp: "SUMMARY:Hello"},
+		{in: Property{Name: "DTSTART", Params: []Param{{"TZID", "Europe/London"}}, Value: "20240101T090000"},
+			exp: "DTSTART;TZID=Europe/London:20240101T090000"},
+		{in: Property{Name: "ATTENDEE", Params: []Param{{"CN", "Doe, John"}}, Value: "mailto:john@example.com"},
+			exp: `ATTENDEE;CN="Doe, John":mailto:john@example.com`},
+	}
+
+	for i, c := range cases {
+		if c.in.String() != c.exp {
+			t.Errorf("%d: got %s, want %s", i, c.in, c.exp)
+		}
+		p, err := parseProperty(c.exp)
+		if err != nil || p.String() != c.exp {
+			t.Errorf("%d: got %s, %v", i, p, err)
+		}
+	}
+}
+
+func TestFoldingRoundTrip(t *testing.T) {
+	cases := []string{
+		"SUMMARY:short",
+		"DESCRIPTION:" + strings.Repeat("x", 63),  // exactly 75
+		"DESCRIPTION:" + strings.Repeat("x", 64),  // 76
+		"DESCRIPTION:" + strings.Repeat("x", 300), // several folds
+		"DESCRIPTION:" + strings.Repeat("é", 100), // two-byte runes
+		"DESCRIPTION:" + strings.Repeat("日本", 60), // three-byte runes
+	}
+
+	for i, c := range cases {
+		buf := &strings.Builder{}
+		fw := &foldingWriter{w: buf}
+		fw.writeLine(c)
+		fw.writeLine("END:X")
+
+		if fw.err != nil || fw.n != int64(buf.Len()) {
+			t.Errorf("%d: %v %d %d", i, fw.err, fw.n, buf.Len())
+		}
+
+		physical := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+		for j, line := range physical {
+			if len(line) > maxLineOctets {
+				t.Errorf("%d: line %d has %d octets", i, j, len(line))
+			}
+			if !utf8.ValidString(line) {
+				t.Errorf("%d: line %d splits a UTF-8 sequence", i, j)
+			}
+		}
+
+		u := newUnfolder(strings.NewReader(buf.String()))
+		got, ok := u.next()
+		if !ok || got != c {
+			t.Errorf("%d: got %q", i, got)
+		}
+		got, ok = u.next()
+		if !ok || got != "END:X" {
+			t.Errorf("%d: got %q", i, got)
+		}
+		if _, ok = u.next(); ok {
+			t.Errorf("%d: unexpected extra line", i)
+		}
+	}
+}
+
+func TestUnfolderAcceptsLFAndTabs(t *testing.T) {
+	u := newUnfolder(strings.NewReader("DESCRIPTION:one\n two\n\tthree\n\nSUMMARY:x"))
+
+	got, _ := u.next()
+	if got != "DESCRIPTION:onetwothree" {
+		t.Errorf("got %q", got)
+	}
+	got, _ = u.next()
+	if got != "SUMMARY:x" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	cases := []struct {
+		in, exp string
+	}{
+		{in: "plain", exp: "plain"},
+		{in: `a\b`, exp: `a\\b`},
+		{in: "a;b,c", exp: `a\;b\,c`},
+		{in: "line1\nline2", exp: `line1\nline2`},
+		{in: "line1\r\nline2", exp: `line1\nline2`},
+	}
+
+	for i, c := range cases {
+		if got := EscapeText(c.in); got != c.exp {
+			t.Errorf("%d: got %q, want %q", i, got, c.exp)
+		}
+		if got := UnescapeText(c.exp); got != strings.ReplaceAll(c.in, "\r\n", "\n") {
+			t.Errorf("%d: got %q", i, got)
+		}
+	}
+
+	if got := UnescapeText(`a\Nb`); got != "a\nb" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+	"github.com/rickb777/period"
+)
+
+// Read parses an iCalendar stream containing one VCALENDAR object.
+//
+// Date-times without a zone ("floating" times) are placed in loc. Date-times with a TZID
+// parameter are placed in the corresponding location: the IANA time zone database is
+// used if it recognises the TZID; otherwise the location is built from the STANDARD and
+// DAYLIGHT observances of the matching VTIMEZONE component, so non-IANA TZIDs such as
+// "W. Europe Standard Time" still follow daylight saving. Recurring observances are
+// expanded up to the end of 2100.
+//
+// Events with neither DTEND nor DURATION last one day (all-day events) or are
+// instantaneous (timed events).
+func Read(r io.Reader, loc *time.Location) (*Calendar, error) {
+	u := newUnfolder(r)
+
+	line, ok := u.next()
+	if !ok {
+		if err := u.err(); err != nil {
+			return nil, fmt.Errorf("ics.Read: %w", err)
+		}
+		return nil, fmt.Errorf("ics.Read: no content")
+	}
+
+	if !strings.EqualFold(line, "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("ics.Read: expected BEGIN:VCALENDAR but got %q", line)
+	}
+
+	root, err := readComponent(u, "VCALENDAR")
+	if err != nil {
+		return nil, fmt.Errorf("ics.Read: %w", err)
+	}
+
+	return newCalendar(root, loc)
+}
+
+// MustRead is as per Read except that it panics if the stream cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustRead(r io.Reader, loc *time.Location) *Calendar {
+	cal, err := Read(r, loc)
+	if err != nil {
+		panic(err)
+	}
+	return cal
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; see Read. Floating
+// date-times are placed in UTC because there is no location to give; use Read to place
+// them elsewhere.
+func (cal *Calendar) UnmarshalText(data []byte) error {
+	c, err := Read(bytes.NewReader(data), time.UTC)
+	if err != nil {
+		return err
+	}
+	*cal = *c
+	return nil
+}
+
+// readComponent reads the properties and sub-components up to the END line for a
+// component that has already begun.
+func readComponent(u *unfolder, name string) (Component, error) {
+	c := Component{Name: name}
+	for {
+		line, ok := u.next()
+		if !ok {
+			if err := u.err(); err != nil {
+				return Component{}, err
+			}
+			return Component{}, fmt.Errorf("missing END:%s", name)
+		}
+
+		p, err := parseProperty(line)
+		if err != nil {
+			return Component{}, err
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			sub, err := readComponent(u, strings.ToUpper(p.Value))
+			if err != nil {
+				return Component{}, err
+			}
+			c.Components = append(c.Components, sub)
+
+		case "END":
+			if !strings.EqualFold(p.Value, name) {
+				return Component{}, fmt.Errorf("expected END:%s but got END:%s", name, p.Value)
+			}
+			return c, nil
+
+		default:
+			c.Properties = append(c.Properties, p)
+		}
+	}
+}
+
+func newCalendar(root Component, loc *time.Location) (*Calendar, error) {
+	zones := make(map[string]*time.Location)
+	for _, c := range root.Components {
+		if c.Name == "VTIMEZONE" {
+			if tzid, ok := findProperty(c.Properties, "TZID"); ok {
+				zones[tzid.Value] = vtimezoneLocation(tzid.Value, c)
+			}
+		}
+	}
+
+	cal := &Calendar{Properties: root.Properties}
+	for _, c := range root.Components {
+		switch c.Name {
+		case "VTIMEZONE":
+			// consumed above
+		case "VEVENT":
+			e, err := newEvent(c, zones, loc)
+			if err != nil {
+				return nil, fmt.Errorf("ics.Read: %w", err)
+			}
+			cal.Events = append(cal.Events, e)
+		default:
+			cal.Components = append(cal.Components, c)
+		}
+	}
+	return cal, nil
+}
+
+func newEvent(c Component, zones map[string]*time.Location, loc *time.Location) (Event, error) {
+	e := Event{Components: c.Components}
+	var dtstart, dtend, duration *Property
+
+	for i, p := range c.Properties {
+		switch p.Name {
+		case "UID":
+			e.UID = p.Value
+		case "SUMMARY":
+			e.Summary = UnescapeText(p.Value)
+		case "DESCRIPTION":
+			e.Description = UnescapeText(p.Value)
+		case "LOCATION":
+			e.Location = UnescapeText(p.Value)
+		case "DTSTART":
+			dtstart = &c.Properties[i]
+		case "DTEND":
+			dtend = &c.Properties[i]
+		case "DURATION":
+			duration = &c.Properties[i]
+		default:
+			e.Properties = append(e.Properties, p)
+		}
+	}
+
+	if dtstart == nil {
+		return Event{}, fmt.Errorf("VEVENT %q has no DTSTART", e.UID)
+	}
+
+	start, isDate, err := parseDateTime(*dtstart, zones, loc)
+	if err != nil {
+		return Event{}, fmt.Errorf("VEVENT %q: %w", e.UID, err)
+	}
+
+	end := start
+	switch {
+	case dtend != nil:
+		end, _, err = parseDateTime(*dtend, zones, loc)
+	case duration != nil:
+		var p period.Period
+		p, err = period.Parse(duration.Value)
+		end, _ = p.AddTo(start)
+	case isDate:
+		end = start.AddDate(0, 0, 1)
+	}
+
+	if err != nil {
+		return Event{}, fmt.Errorf("VEVENT %q: %w", e.UID, err)
+	}
+
+	if isDate {
+		e.AllDay = true
+		e.Dates = timespan.BetweenDates(date.NewAt(start), date.NewAt(end))
+	} else {
+		e.Span = timespan.BetweenTimes(start, end)
+	}
+
+	return e, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME property value. A DATE is returned as
+// midnight UTC and the flag is true.
+func parseDateTime(p Property, zones map[string]*time.Location, loc *time.Location) (time.Time, bool, error) {
+	value := p.Value
+	if v, _ := p.Param("VALUE"); strings.EqualFold(v, "DATE") || len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(timespan.RFC5545DateTimeLayout, value[:len(value)-1])
+		return t, false, err
+	}
+
+	if tzid, ok := p.Param("TZID"); ok {
+		if z, exists := zones[tzid]; exists {
+			loc = z
+		} else if z, err := time.LoadLocation(tzid); err == nil {
+			loc = z
+		} else {
+			return time.Time{}, false, fmt.Errorf("%s has unknown TZID %q", p.Name, tzid)
+		}
+	}
+
+	t, err := time.ParseInLocation(timespan.RFC5545DateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// parseOffset parses a UTC offset such as "+0100", "-0530" or "+013045", returning
+// the number of seconds east of UTC.
+func parseOffset(s string) (int, error) {
+	if (len(s) != 5 && len(s) != 7) || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("invalid UTC offset %q", s)
+	}
+
+	n := 0
+	for i, mul := 1, 3600; i < len(s); i, mul = i+2, mul/60 {
+		v, err := strconv.Atoi(s[i : i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid UTC offset %q", s)
+		}
+		n += v * mul
+	}
+
+	if s[0] == '-' {
+		return -n, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+func crlf(s string) string {
+	return strings.ReplaceAll(strings.TrimLeft(s, "\n"), "\n", "\r\n")
+}
+
+const sample = `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Test//EN
+BEGIN:VTIMEZONE
+TZID:Custom/Zone
+BEGIN:STANDARD
+DTSTART:19701025T030000
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+END:STANDARD
+BEGIN:DAYLIGHT
+DTSTART:19700329T020000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+END:DAYLIGHT
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:holiday-1
+DTSTAMP:20240101T000000Z
+DTSTART;VALUE=DATE:20240325
+DTEND;VALUE=DATE:20240328
+SUMMARY:Easter break\, part 1
+DESCRIPTION:Line one\nLine two
+X-CUSTOM;X-PARAM=yes:anything
+END:VEVENT
+BEGIN:VEVENT
+UID:meeting-1
+DTSTART;TZID=Europe/London:20240701T090000
+DTEND;TZID=Europe/London:20240701T103000
+SUMMARY:Planning
+LOCATION:Room 1
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:meeting-2
+DTSTART;TZID=Custom/Zone:20240701T090000
+DURATION:PT2H
+END:VEVENT
+BEGIN:VEVENT
+UID:utc-1
+DTSTART:20240701T120000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:floating-1
+DTSTART:20240701T120000
+DTEND:20240701T130000
+END:VEVENT
+BEGIN:VEVENT
+UID:single-day
+DTSTART;VALUE=DATE:20240401
+END:VEVENT
+BEGIN:VTODO
+UID:todo-1
+SUMMARY:Something
+END:VTODO
+END:VCALENDAR
+`
+
+func TestRead(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	cal, err := Read(strings.NewReader(crlf(sample)), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := cal.Property("prodid"); v.Value != "-//Example//Test//EN" {
+		t.Errorf("got %v", v)
+	}
+	if len(cal.Events) != 6 {
+		t.Fatalf("got %d events", len(cal.Events))
+	}
+	if len(cal.Components) != 1 || cal.Components[0].Name != "VTODO" {
+		t.Errorf("got %v", cal.Components)
+	}
+
+	e := cal.Events[0]
+	if !e.AllDay || e.Dates != timespan.BetweenDates(date.New(2024, 3, 25), date.New(2024, 3, 28)) {
+		t.Errorf("got %v", e.Dates)
+	}
+	if e.Dates.Days() != 3 {
+		t.Errorf("got %d days", e.Dates.Days())
+	}
+	if e.Summary != "Easter break, part 1" || e.Description != "Line one\nLine two" {
+		t.Errorf("got %q %q", e.Summary, e.Description)
+	}
+	if len(e.Properties) != 2 || e.Properties[0].Name != "DTSTAMP" {
+		t.Errorf("got %v", e.Properties)
+	}
+	if p, ok := e.Property("X-CUSTOM"); !ok || p.Value != "anything" {
+		t.Errorf("got %v", p)
+	}
+
+	e = cal.Events[1]
+	exp := timespan.BetweenTimes(time.Date(2024, 7, 1, 9, 0, 0, 0, london), time.Date(2024, 7, 1, 10, 30, 0, 0, london))
+	if e.AllDay || !e.Span.Equal(exp) || e.Span.Start().Location().String() != "Europe/London" {
+		t.Errorf("got %v", e.Span)
+	}
+	if e.Location != "Room 1" || len(e.Components) != 1 || e.Components[0].Name != "VALARM" {
+		t.Errorf("got %v", e)
+	}
+
+	// not in the IANA database, and the VTIMEZONE has no recurring observances, so the
+	// STANDARD observance from 1970 onwards is used
+	e = cal.Events[2]
+	if e.Span.Start().Format(time.RFC3339) != "2024-07-01T09:00:00+01:00" || e.Span.Duration() != 2*time.Hour {
+		t.Errorf("got %v", e.Span)
+	}
+
+	e = cal.Events[3]
+	if !e.Span.Start().Equal(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)) || e.Span.Duration() != 0 {
+		t.Errorf("got %v", e.Span)
+	}
+
+	e = cal.Events[4]
+	if e.Span.Start() != time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC) || e.Span.Duration() != time.Hour {
+		t.Errorf("got %v", e.Span)
+	}
+
+	e = cal.Events[5]
+	if !e.AllDay || e.Dates != timespan.OneDayRange(date.New(2024, 4, 1)) {
+		t.Errorf("got %v", e.Dates)
+	}
+}
+
+const outlook = `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:Microsoft Exchange Server 2010
+BEGIN:VTIMEZONE
+TZID:W. Europe Standard Time
+BEGIN:STANDARD
+DTSTART:16010101T030000
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=10
+END:STANDARD
+BEGIN:DAYLIGHT
+DTSTART:16010101T020000
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=-1SU;BYMONTH=3
+END:DAYLIGHT
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:summer
+DTSTART;TZID=W. Europe Standard Time:20240701T090000
+DTEND;TZID=W. Europe Standard Time:20240701T100000
+END:VEVENT
+BEGIN:VEVENT
+UID:winter
+DTSTART;TZID=W. Europe Standard Time:20240115T090000
+DTEND;TZID=W. Europe Standard Time:20240115T100000
+END:VEVENT
+BEGIN:VEVENT
+UID:clocks-go-forward
+DTSTART;TZID=W. Europe Standard Time:20240331T010000
+DTEND;TZID=W. Europe Standard Time:20240331T040000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestReadNonIANATimeZone(t *testing.T) {
+	cal, err := Read(strings.NewReader(crlf(outlook)), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []time.Time{
+		time.Date(2024, 7, 1, 7, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	for i, e := range cal.Events {
+		if !e.Span.Start().Equal(exp[i]) || e.Span.Start().Location().String() != "W. Europe Standard Time" {
+			t.Errorf("%d: got %v", i, e.Span)
+		}
+	}
+
+	// the clocks go forward at 02:00, so the event lasts two hours
+	if cal.Events[2].Span.Duration() != 2*time.Hour {
+		t.Errorf("got %v", cal.Events[2].Span)
+	}
+
+	// writing keeps the daylight-saving rules of the zone
+	s := cal.String()
+	for _, want := range []string{
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240331T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20241027T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("missing %q in\n%s", want, s)
+		}
+	}
+
+	again, err := Read(strings.NewReader(s), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, e := range again.Events {
+		if !e.Span.Equal(cal.Events[i].Span) {
+			t.Errorf("%d: got %v, want %v", i, e.Span, cal.Events[i].Span)
+		}
+	}
+}
+
+func TestReadFloatingLocation(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	cal := MustRead(strings.NewReader(crlf(`
+BEGIN:VCALENDAR
+BEGIN:VEVENT
+DTSTART:20240101T090000
+DURATION:P1D
+END:VEVENT
+END:VCALENDAR
+`)), ny)
+
+	e := cal.Events[0]
+	if e.Span.Start() != time.Date(2024, 1, 1, 9, 0, 0, 0, ny) || e.Span.Duration() != 24*time.Hour {
+		t.Errorf("got %v", e.Span)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"BEGIN:VEVENT\nEND:VEVENT\n",
+		"BEGIN:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nnonsense\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024\nEND:VEVENT\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=No/Where:20240101T090000\nEND:VEVENT\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101T090000\nDURATION:1H\nEND:VEVENT\nEND:VCALENDAR\n",
+	}
+
+	for i, c := range cases {
+		if _, err := Read(strings.NewReader(c), time.UTC); err == nil {
+			t.Errorf("%d: expected an error", i)
+		} else if !strings.HasPrefix(err.Error(), "ics.Read: ") {
+			t.Errorf("%d: got %v", i, err)
+		}
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	cases := []struct {
+		in  string
+		exp int
+	}{
+		{in: "+0000", exp: 0},
+		{in: "+0100", exp: 3600},
+		{in: "-0530", exp: -19800},
+		{in: "+013045", exp: 5445},
+	}
+
+	for i, c := range cases {
+		n, err := parseOffset(c.in)
+		if err != nil || n != c.exp {
+			t.Errorf("%d: got %d %v", i, n, err)
+		}
+		if formatOffset(c.exp) != c.in {
+			t.Errorf("%d: got %s", i, formatOffset(c.exp))
+		}
+	}
+
+	for i, c := range []string{"", "0100", "+01", "+01x0"} {
+		if _, err := parseOffset(c); err == nil {
+			t.Errorf("%d: expected an error for %q", i, c)
+		}
+	}
+}
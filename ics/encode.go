@@ -0,0 +1,238 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// WriteTo writes the calendar in iCalendar format, with CRLF line endings and lines
+// folded at 75 octets.
+//
+// VERSION and PRODID properties are added if absent. A VTIMEZONE component is written
+// for every location used by the timed events, other than UTC and time.Local; the
+// VTIMEZONE lists the transitions that occur during the years spanned by the events.
+// Timed events in UTC are written with the "Z" suffix and those in time.Local are
+// written as floating times.
+func (cal Calendar) WriteTo(w io.Writer) (int64, error) {
+	fw := &foldingWriter{w: w}
+
+	fw.writeLine("BEGIN:VCALENDAR")
+	if _, ok := cal.Property("VERSION"); !ok {
+		fw.writeLine("VERSION:2.0")
+	}
+	if _, ok := cal.Property("PRODID"); !ok {
+		fw.writeLine("PRODID:" + DefaultProductID)
+	}
+	writeProperties(fw, cal.Properties)
+
+	for _, vtz := range cal.vtimezones() {
+		writeComponent(fw, vtz)
+	}
+
+	for _, e := range cal.Events {
+		writeComponent(fw, e.component())
+	}
+
+	for _, c := range cal.Components {
+		writeComponent(fw, c)
+	}
+
+	fw.writeLine("END:VCALENDAR")
+	return fw.n, fw.err
+}
+
+// String returns the calendar in iCalendar format.
+func (cal Calendar) String() string {
+	buf := &strings.Builder{}
+	cal.WriteTo(buf)
+	return buf.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; see WriteTo. It is the
+// counterpart of UnmarshalText.
+func (cal Calendar) MarshalText() ([]byte, error) {
+	buf := &strings.Builder{}
+	_, err := cal.WriteTo(buf)
+	return []byte(buf.String()), err
+}
+
+func writeComponent(fw *foldingWriter, c Component) {
+	fw.writeLine("BEGIN:" + c.Name)
+	writeProperties(fw, c.Properties)
+	for _, sub := range c.Components {
+		writeComponent(fw, sub)
+	}
+	fw.writeLine("END:" + c.Name)
+}
+
+func writeProperties(fw *foldingWriter, props []Property) {
+	for _, p := range props {
+		fw.writeLine(p.String())
+	}
+}
+
+// component converts the event to a generic VEVENT component.
+func (e Event) component() Component {
+	c := Component{Name: "VEVENT", Components: e.Components}
+
+	if e.UID != "" {
+		c.Properties = append(c.Properties, Property{Name: "UID", Value: e.UID})
+	}
+
+	if e.AllDay {
+		c.Properties = append(c.Properties,
+			Property{Name: "DTSTART", Params: []Param{{"VALUE", "DATE"}}, Value: formatDate(e.Dates.Start().MidnightUTC())},
+			Property{Name: "DTEND", Params: []Param{{"VALUE", "DATE"}}, Value: formatDate(e.Dates.End().MidnightUTC())},
+		)
+	} else {
+		span := e.Span.Normalise()
+		loc := span.Start().Location()
+		c.Properties = append(c.Properties,
+			dateTimeProperty("DTSTART", span.Start(), loc),
+			dateTimeProperty("DTEND", span.End().In(loc), loc),
+		)
+	}
+
+	c.Properties = appendText(c.Properties, "SUMMARY", e.Summary)
+	c.Properties = appendText(c.Properties, "DESCRIPTION", e.Description)
+	c.Properties = appendText(c.Properties, "LOCATION", e.Location)
+	c.Properties = append(c.Properties, e.Properties...)
+	return c
+}
+
+func appendText(props []Property, name, value string) []Property {
+	if value == "" {
+		return props
+	}
+	return append(props, Property{Name: name, Value: EscapeText(value)})
+}
+
+func dateTimeProperty(name string, t time.Time, loc *time.Location) Property {
+	switch loc {
+	case time.UTC:
+		return Property{Name: name, Value: t.Format(timespan.RFC5545DateTimeZulu)}
+	case time.Local:
+		return Property{Name: name, Value: t.Format(timespan.RFC5545DateTimeLayout)}
+	}
+	return Property{Name: name, Params: []Param{{"TZID", loc.String()}}, Value: t.Format(timespan.RFC5545DateTimeLayout)}
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// vtimezones builds the VTIMEZONE components for the locations used by the timed events.
+func (cal Calendar) vtimezones() []Component {
+	type extent struct {
+		loc      *time.Location
+		from, to time.Time
+	}
+
+	// locations are identified by name because time.LoadLocation returns a new
+	// value on every call
+	var names []string
+	extents := make(map[string]*extent)
+
+	for _, e := range cal.Events {
+		if e.AllDay {
+			continue
+		}
+
+		span := e.Span.Normalise()
+		loc := span.Start().Location()
+		if loc == time.UTC || loc == time.Local {
+			continue
+		}
+
+		x, exists := extents[loc.String()]
+		if !exists {
+			extents[loc.String()] = &extent{loc: loc, from: span.Start(), to: span.End()}
+			names = append(names, loc.String())
+		} else {
+			if span.Start().Before(x.from) {
+				x.from = span.Start()
+			}
+			if span.End().After(x.to) {
+				x.to = span.End()
+			}
+		}
+	}
+
+	vtzs := make([]Component, 0, len(names))
+	for _, name := range names {
+		x := extents[name]
+		vtzs = append(vtzs, vtimezone(x.loc, x.from.In(x.loc).Year(), x.to.In(x.loc).Year()))
+	}
+	return vtzs
+}
+
+// vtimezone builds a VTIMEZONE component containing the observance in force at the start
+// of the first year and every transition up to the end of the last year.
+func vtimezone(loc *time.Location, firstYear, lastYear int) Component {
+	from := time.Date(firstYear, time.January, 1, 0, 0, 0, 0, loc)
+	to := time.Date(lastYear+1, time.January, 1, 0, 0, 0, 0, loc)
+
+	c := Component{Name: "VTIMEZONE", Properties: []Property{{Name: "TZID", Value: loc.String()}}}
+
+	_, offset := from.Zone()
+	c.Components = append(c.Components, observance(from, offset))
+
+	// ZoneBounds gives each transition exactly, however close together they are;
+	// a transition is kept if it changes the name, the offset or daylight saving
+	for t := from; ; {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.Before(to) {
+			break
+		}
+		name1, o1 := t.Zone()
+		name2, o2 := end.Zone()
+		if name1 != name2 || o1 != o2 || t.IsDST() != end.IsDST() {
+			c.Components = append(c.Components, observance(end, o1))
+		}
+		t = end
+	}
+
+	return c
+}
+
+// observance builds a STANDARD or DAYLIGHT component for the zone in force at t,
+// which follows a zone with offsetFrom.
+func observance(t time.Time, offsetFrom int) Component {
+	name, offsetTo := t.Zone()
+	kind := "STANDARD"
+	if t.IsDST() {
+		kind = "DAYLIGHT"
+	}
+
+	// DTSTART is expressed as local time in the preceding zone
+	start := t.In(time.FixedZone("", offsetFrom)).Format(timespan.RFC5545DateTimeLayout)
+
+	return Component{Name: kind, Properties: []Property{
+		{Name: "DTSTART", Value: start},
+		{Name: "TZOFFSETFROM", Value: formatOffset(offsetFrom)},
+		{Name: "TZOFFSETTO", Value: formatOffset(offsetTo)},
+		{Name: "TZNAME", Value: name},
+	}}
+}
+
+// formatOffset formats a UTC offset in seconds as "+hhmm", or "+hhmmss" when necessary.
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	h, m, s := offset/3600, offset/60%60, offset%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, h, m)
+}
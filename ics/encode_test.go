@@ -0,0 +1,209 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+func TestWriteAllDayAndUTC(t *testing.T) {
+	cal := Calendar{Events: []Event{
+		NewAllDayEvent("a1", "Holiday; long", timespan.BetweenDates(date.New(2024, 3, 25), date.New(2024, 3, 28))),
+		NewTimedEvent("t1", "Call", timespan.TimeSpanOf(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), time.Hour)),
+	}}
+
+	exp := crlf(`
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//rickb777//date//EN
+BEGIN:VEVENT
+UID:a1
+DTSTART;VALUE=DATE:20240325
+DTEND;VALUE=DATE:20240328
+SUMMARY:Holiday\; long
+END:VEVENT
+BEGIN:VEVENT
+UID:t1
+DTSTART:20240701T120000Z
+DTEND:20240701T130000Z
+SUMMARY:Call
+END:VEVENT
+END:VCALENDAR
+`)
+
+	if s := cal.String(); s != exp {
+		t.Errorf("got\n%s\nwant\n%s", s, exp)
+	}
+
+	buf := &strings.Builder{}
+	n, err := cal.WriteTo(buf)
+	if err != nil || n != int64(len(exp)) {
+		t.Errorf("got %d %v", n, err)
+	}
+}
+
+func TestWriteTZID(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	cal := Calendar{Events: []Event{
+		NewTimedEvent("t1", "Meeting", timespan.TimeSpanOf(time.Date(2024, 7, 1, 9, 0, 0, 0, london), 90*time.Minute)),
+	}}
+
+	s := cal.String()
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/London\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20240101T000000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0000\r\nTZNAME:GMT\r\nEND:STANDARD\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240331T010000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0100\r\nTZNAME:BST\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20241027T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0000\r\nTZNAME:GMT\r\nEND:STANDARD\r\n",
+		"DTSTART;TZID=Europe/London:20240701T090000\r\n",
+		"DTEND;TZID=Europe/London:20240701T103000\r\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("missing %q in\n%s", want, s)
+		}
+	}
+
+	if strings.Count(s, "BEGIN:VTIMEZONE") != 1 {
+		t.Errorf("got\n%s", s)
+	}
+}
+
+func TestWriteVTimezoneSpansYears(t *testing.T) {
+	// distinct *time.Location values for the same zone produce one VTIMEZONE
+	l1, _ := time.LoadLocation("Europe/Paris")
+	l2, _ := time.LoadLocation("Europe/Paris")
+	cal := Calendar{Events: []Event{
+		NewTimedEvent("1", "", timespan.TimeSpanOf(time.Date(2023, 7, 1, 9, 0, 0, 0, l1), time.Hour)),
+		NewTimedEvent("2", "", timespan.TimeSpanOf(time.Date(2025, 7, 1, 9, 0, 0, 0, l2), time.Hour)),
+	}}
+
+	vtzs := cal.vtimezones()
+	if len(vtzs) != 1 {
+		t.Fatalf("got %d", len(vtzs))
+	}
+	// initial observance plus two transitions per year
+	if n := len(vtzs[0].Components); n != 7 {
+		t.Errorf("got %d observances", n)
+	}
+}
+
+func TestWriteVTimezoneCloseTransitions(t *testing.T) {
+	// a one-off daylight saving of six hours, then a change of abbreviation only
+	at := func(month time.Month, hour int) int64 {
+		return time.Date(2024, month, 1, hour, 0, 0, 0, time.UTC).Unix()
+	}
+	aaa, bbb, ccc := zoneType{name: "AAA"}, zoneType{offset: 3600, isDST: true, name: "BBB"}, zoneType{name: "CCC"}
+	loc, err := time.LoadLocationFromTZData("Test/Zone", tzdata(aaa, []transition{
+		{at: at(time.March, 0), from: 0, zone: bbb},
+		{at: at(time.March, 6), from: 3600, zone: aaa},
+		{at: at(time.June, 0), from: 0, zone: ccc},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cal := Calendar{Components: []Component{vtimezone(loc, 2024, 2024)}}
+	s := cal.String()
+	for _, want := range []string{
+		"BEGIN:STANDARD\r\nDTSTART:20240101T000000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0000\r\nTZNAME:AAA\r\nEND:STANDARD\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20240301T000000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0100\r\nTZNAME:BBB\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20240301T070000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0000\r\nTZNAME:AAA\r\nEND:STANDARD\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20240601T000000\r\nTZOFFSETFROM:+0000\r\nTZOFFSETTO:+0000\r\nTZNAME:CCC\r\nEND:STANDARD\r\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("missing %q in\n%s", want, s)
+		}
+	}
+
+	if n := len(cal.Components[0].Components); n != 4 {
+		t.Errorf("got %d observances", n)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cal := MustRead(strings.NewReader(crlf(sample)), time.UTC)
+
+	// VTIMEZONE components are regenerated, so the second rendering must equal the first
+	s1 := cal.String()
+	cal2 := MustRead(strings.NewReader(s1), time.UTC)
+	s2 := cal2.String()
+	if s1 != s2 {
+		t.Errorf("got\n%s\nwant\n%s", s2, s1)
+	}
+
+	for i := range cal.Events {
+		e1, e2 := cal.Events[i], cal2.Events[i]
+		if e1.UID != e2.UID || e1.Summary != e2.Summary || e1.Description != e2.Description ||
+			e1.Dates != e2.Dates || !e1.Span.Equal(e2.Span) ||
+			len(e1.Properties) != len(e2.Properties) || len(e1.Components) != len(e2.Components) {
+			t.Errorf("%d: got %+v, want %+v", i, e2, e1)
+		}
+	}
+
+	for _, want := range []string{
+		"PRODID:-//Example//Test//EN\r\n",
+		"X-CUSTOM;X-PARAM=yes:anything\r\n",
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nTRIGGER:-PT15M\r\nEND:VALARM\r\n",
+		"BEGIN:VTODO\r\nUID:todo-1\r\n",
+		"DTSTART;TZID=Custom/Zone:20240701T090000\r\nDTEND;TZID=Custom/Zone:20240701T110000\r\n",
+		"DTSTART:20240701T120000Z\r\nDTEND:20240701T130000Z\r\n",
+	} {
+		if !strings.Contains(s1, want) {
+			t.Errorf("missing %q in\n%s", want, s1)
+		}
+	}
+
+	if strings.Count(s1, "VERSION:") != 1 {
+		t.Errorf("got\n%s", s1)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	cal := Calendar{Events: []Event{NewAllDayEvent("a", strings.Repeat("long ", 30), timespan.OneDayRange(date.New(2024, 1, 1)))}}
+	b, err := cal.MarshalText()
+	if err != nil || string(b) != cal.String() {
+		t.Errorf("got %v", err)
+	}
+	for _, line := range strings.Split(string(b), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("got %q", line)
+		}
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	cal := MustRead(strings.NewReader(crlf(sample)), time.UTC)
+	b, err := cal.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cal2 Calendar
+	err = cal2.UnmarshalText(b)
+	if err != nil || cal2.String() != string(b) {
+		t.Errorf("got %v\n%s\nwant\n%s", err, cal2.String(), b)
+	}
+
+	floating := crlf(`
+BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:f1
+DTSTART:20240701T090000
+END:VEVENT
+END:VCALENDAR
+`)
+	err = cal2.UnmarshalText([]byte(floating))
+	if err != nil || len(cal2.Events) != 1 || !cal2.Events[0].Span.Start().Equal(time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %+v %v", cal2.Events, err)
+	}
+
+	if err = cal2.UnmarshalText([]byte("BEGIN:VCARD\r\n")); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ics
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/date/v2/rrule"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// lastTransitionYear is the last year for which the recurring observances of a VTIMEZONE
+// are expanded; the observance in force at the end of that year continues thereafter.
+const lastTransitionYear = 2100
+
+// zoneType is a UTC offset in seconds east of UTC, with its abbreviation.
+type zoneType struct {
+	offset int
+	isDST  bool
+	name   string
+}
+
+// transition is the instant, in Unix seconds, at which an observance comes into force.
+type transition struct {
+	at   int64
+	from int // the offset before the transition
+	zone zoneType
+}
+
+// vtimezoneLocation finds the location for a VTIMEZONE. The IANA database is preferred;
+// otherwise, the location is built from the transitions given by the STANDARD and
+// DAYLIGHT observances, so that it follows daylight saving as the VTIMEZONE specifies.
+func vtimezoneLocation(tzid string, vtz Component) *time.Location {
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+
+	var transitions []transition
+	for _, obs := range vtz.Components {
+		if obs.Name == "STANDARD" || obs.Name == "DAYLIGHT" {
+			transitions = append(transitions, observanceTransitions(obs)...)
+		}
+	}
+
+	if len(transitions) == 0 {
+		return time.FixedZone(tzid, 0)
+	}
+
+	slices.SortStableFunc(transitions, func(a, b transition) int { return cmp.Compare(a.at, b.at) })
+
+	// only the transitions that change the zone are needed
+	changes := transitions[:1]
+	for _, t := range transitions[1:] {
+		if t.zone != changes[len(changes)-1].zone {
+			changes = append(changes, t)
+		}
+	}
+
+	// the zone before the first transition has the offset it starts from
+	before := zoneType{offset: changes[0].from, name: formatOffset(changes[0].from)}
+	for _, t := range changes {
+		if t.zone.offset == before.offset {
+			before = t.zone
+			break
+		}
+	}
+
+	loc, err := time.LoadLocationFromTZData(tzid, tzdata(before, changes))
+	if err != nil {
+		return time.FixedZone(tzid, changes[len(changes)-1].zone.offset)
+	}
+	return loc
+}
+
+// observanceTransitions lists the onsets of a STANDARD or DAYLIGHT observance: its DTSTART
+// and those given by its RRULE and RDATE properties. Onsets are wall-clock times in the
+// zone that precedes the observance, i.e. at TZOFFSETFROM.
+func observanceTransitions(obs Component) []transition {
+	dtstart, ok1 := findProperty(obs.Properties, "DTSTART")
+	from, ok2 := findProperty(obs.Properties, "TZOFFSETFROM")
+	to, ok3 := findProperty(obs.Properties, "TZOFFSETTO")
+	if !ok1 || !ok2 || !ok3 {
+		return nil
+	}
+
+	offsetFrom, err1 := parseOffset(from.Value)
+	offsetTo, err2 := parseOffset(to.Value)
+	start, err3 := time.Parse(timespan.RFC5545DateTimeLayout, dtstart.Value)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil
+	}
+
+	zone := zoneType{offset: offsetTo, isDST: obs.Name == "DAYLIGHT", name: formatOffset(offsetTo)}
+	if name, ok := findProperty(obs.Properties, "TZNAME"); ok && name.Value != "" {
+		zone.name = name.Value
+	}
+
+	// wall-clock times are parsed as though they were UTC
+	onset := func(wall time.Time) transition {
+		return transition{at: wall.Unix() - int64(offsetFrom), from: offsetFrom, zone: zone}
+	}
+
+	transitions := []transition{onset(start)}
+	for _, p := range obs.Properties {
+		switch p.Name {
+		case "RRULE":
+			r, err := rrule.Parse(p.Value)
+			if err != nil {
+				continue
+			}
+
+			until := r.Until.Unix()
+			if r.UntilIsFloating {
+				until -= int64(offsetFrom)
+			}
+
+			cl := clock.NewAt(start)
+			it := r.Dates(date.NewAt(start))
+			for d, ok := it.Next(); ok && d.Year() <= lastTransitionYear; d, ok = it.Next() {
+				t := onset(d.MidnightUTC().Add(cl.DurationSinceMidnight()))
+				if !r.Until.IsZero() && !r.UntilIsDate && t.at > until {
+					break
+				}
+				transitions = append(transitions, t)
+			}
+
+		case "RDATE":
+			for _, v := range strings.Split(p.Value, ",") {
+				if wall, err := time.Parse(timespan.RFC5545DateTimeLayout, v); err == nil {
+					transitions = append(transitions, onset(wall))
+				}
+			}
+		}
+	}
+	return transitions
+}
+
+// tzdata encodes the transitions in the TZif format (RFC8536), from which the time package
+// can construct a location. The zone before the first transition is zone type 0, which no
+// transition uses.
+func tzdata(before zoneType, transitions []transition) []byte {
+	types := []zoneType{before}
+	var abbreviations []byte
+	var times, indexes []byte
+
+	for _, t := range transitions {
+		i := slices.Index(types[1:], t.zone) + 1
+		if i == 0 {
+			types = append(types, t.zone)
+			i = len(types) - 1
+		}
+		times = binary.BigEndian.AppendUint64(times, uint64(t.at))
+		indexes = append(indexes, byte(i))
+	}
+
+	var zones []byte
+	for _, z := range types {
+		k := strings.Index(string(abbreviations), z.name+"\x00")
+		if k < 0 {
+			k = len(abbreviations)
+			abbreviations = append(abbreviations, z.name+"\x00"...)
+		}
+		zones = binary.BigEndian.AppendUint32(zones, uint32(int32(z.offset)))
+		zones = append(zones, boolByte(z.isDST), byte(k))
+	}
+
+	// zone types and abbreviations are indexed by bytes
+	if len(types) > 256 || len(abbreviations) > 256 {
+		return nil
+	}
+
+	// the time package only reads the 64-bit data of version 2, so the 32-bit data is empty
+	header := func(data []byte, timecnt, typecnt, charcnt int) []byte {
+		data = append(data, "TZif2"...)
+		data = append(data, make([]byte, 15)...)
+		for _, n := range []int{0, 0, 0, timecnt, typecnt, charcnt} {
+			data = binary.BigEndian.AppendUint32(data, uint32(n))
+		}
+		return data
+	}
+
+	data := header(nil, 0, 0, 0)
+	data = header(data, len(transitions), len(types), len(abbreviations))
+	data = append(data, times...)
+	data = append(data, indexes...)
+	data = append(data, zones...)
+	return append(data, abbreviations...)
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
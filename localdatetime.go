@@ -0,0 +1,252 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2/clock"
+)
+
+// LocalDateTime is a date and a wall-clock time without any time zone, such as
+// "2024-03-31T02:30" entered by a user before their time zone is known. It corresponds
+// to the SQL type TIMESTAMP WITHOUT TIME ZONE.
+//
+// A LocalDateTime is not an instant: it only becomes one when a location is chosen (see
+// In). So arithmetic treats every day as having 24 hours.
+//
+// The clock is always at least midnight and before 24:00, so LocalDateTime values can be
+// compared using == and !=, as well as with Compare, Before and After. The zero value is
+// midnight at the start of Zero, i.e. 0001-01-01T00:00:00.
+type LocalDateTime struct {
+	date  Date
+	clock clock.Clock
+}
+
+// NewLocalDateTime constructs a LocalDateTime from a date and a clock time. Clock times
+// that are negative or 24 hours or more carry into the previous or following days, so
+// 2024-03-31 with 25:00 is 2024-04-01T01:00.
+func NewLocalDateTime(d Date, c clock.Clock) LocalDateTime {
+	days := c / clock.Day
+	c -= days * clock.Day
+	if c < 0 {
+		c += clock.Day
+		days--
+	}
+	return LocalDateTime{date: d + Date(days), clock: c}
+}
+
+// LocalDateTimeAt returns the date and wall-clock time of t, in t's location.
+func LocalDateTimeAt(t time.Time) LocalDateTime {
+	return LocalDateTime{date: NewAt(t), clock: clock.NewAt(t)}
+}
+
+// Date returns the date part.
+func (ldt LocalDateTime) Date() Date {
+	return ldt.date
+}
+
+// Clock returns the clock time, which is at least midnight and before 24:00.
+func (ldt LocalDateTime) Clock() clock.Clock {
+	return ldt.clock
+}
+
+// Compare returns -1 if ldt is before other, +1 if it is after, and 0 if they are equal.
+func (ldt LocalDateTime) Compare(other LocalDateTime) int {
+	switch {
+	case ldt.date < other.date:
+		return -1
+	case ldt.date > other.date:
+		return 1
+	case ldt.clock < other.clock:
+		return -1
+	case ldt.clock > other.clock:
+		return 1
+	}
+	return 0
+}
+
+// Before tests whether ldt is before other.
+func (ldt LocalDateTime) Before(other LocalDateTime) bool {
+	return ldt.Compare(other) < 0
+}
+
+// After tests whether ldt is after other.
+func (ldt LocalDateTime) After(other LocalDateTime) bool {
+	return ldt.Compare(other) > 0
+}
+
+// IsZero tests whether ldt is the zero value.
+func (ldt LocalDateTime) IsZero() bool {
+	return ldt == LocalDateTime{}
+}
+
+// AddDate returns the LocalDateTime on the date given by Date.AddDate, at the same clock time.
+func (ldt LocalDateTime) AddDate(years, months, days int) LocalDateTime {
+	return LocalDateTime{date: ldt.date.AddDate(years, months, days), clock: ldt.clock}
+}
+
+// AddDays returns the LocalDateTime a number of days later (or earlier if negative), at
+// the same clock time.
+func (ldt LocalDateTime) AddDays(days int) LocalDateTime {
+	return LocalDateTime{date: ldt.date + Date(days), clock: ldt.clock}
+}
+
+// Add returns the LocalDateTime a duration later (or earlier if negative). Every day is
+// taken to have 24 hours.
+func (ldt LocalDateTime) Add(d time.Duration) LocalDateTime {
+	days := d / (24 * time.Hour)
+	rest := clock.Clock(d - days*24*time.Hour)
+	return NewLocalDateTime(ldt.date+Date(days), ldt.clock+rest)
+}
+
+// Sub returns the duration ldt-other, taking every day to have 24 hours. If the result
+// exceeds the maximum (or minimum) value that can be stored in a Duration, the maximum
+// (or minimum) duration will be returned.
+func (ldt LocalDateTime) Sub(other LocalDateTime) time.Duration {
+	const maxDays = math.MaxInt64 / int64(24*time.Hour)
+
+	days := int64(ldt.date - other.date)
+	switch {
+	case days > maxDays:
+		return math.MaxInt64
+	case days < -maxDays:
+		return math.MinInt64
+	}
+
+	whole := time.Duration(days) * 24 * time.Hour
+	diff := time.Duration(ldt.clock - other.clock)
+	switch {
+	case diff > 0 && whole > math.MaxInt64-diff:
+		return math.MaxInt64
+	case diff < 0 && whole < math.MinInt64-diff:
+		return math.MinInt64
+	}
+	return whole + diff
+}
+
+// In converts ldt to the instant at which it occurs in a location. When the clocks go
+// forward or back, some wall-clock times do not exist and others exist twice; the
+// resolution decides what to do in these cases.
+func (ldt LocalDateTime) In(loc *time.Location, res Resolution) (time.Time, error) {
+	return resolve(ldt.date, ldt.clock, loc, res)
+}
+
+// Convert converts ldt in the location from to the date and wall-clock time at the
+// same instant in the location to. The resolution applies in the location from; see In.
+func (ldt LocalDateTime) Convert(from, to *time.Location, res Resolution) (LocalDateTime, error) {
+	t, err := ldt.In(from, res)
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+	return LocalDateTimeAt(t.In(to)), nil
+}
+
+// String formats ldt in ISO 8601 extended format, e.g. "2024-03-31T02:30:00". Fractions
+// of a second are included only when they are not zero, with either millisecond or
+// nanosecond precision as per clock.Clock.String.
+func (ldt LocalDateTime) String() string {
+	buf := &strings.Builder{}
+	buf.Grow(32)
+	ldt.date.WriteTo(buf)
+	buf.WriteByte('T')
+	if ldt.clock%clock.Second != 0 {
+		buf.WriteString(ldt.clock.String())
+	} else {
+		buf.WriteString(ldt.clock.HhMmSs())
+	}
+	return buf.String()
+}
+
+// MustParseLocalDateTime is as per ParseLocalDateTime except that it panics if the string
+// cannot be parsed. This is intended for setup code; don't use it for user inputs.
+func MustParseLocalDateTime(value string) LocalDateTime {
+	ldt, err := ParseLocalDateTime(value)
+	if err != nil {
+		panic(err)
+	}
+	return ldt
+}
+
+// ParseLocalDateTime parses an ISO 8601 date and time such as "2024-03-31T02:30" or
+// "2024-03-31T02:30:00.5". The date is parsed as per ParseISO and the time as per
+// clock.Parse. A space may be used instead of 'T', as in SQL. If there is no time,
+// the result is at midnight. A time of "24:00" is midnight at the end of the day, so
+// it is the start of the following day.
+//
+// Time zone designators such as "Z" or "+01:00" are not allowed: use time.Parse and
+// LocalDateTimeAt for those.
+func ParseLocalDateTime(value string) (LocalDateTime, error) {
+	ds, cs, hasTime := strings.Cut(value, "T")
+	if !hasTime {
+		ds, cs, hasTime = strings.Cut(value, " ")
+	}
+
+	d, err := ParseISO(ds)
+	if err != nil {
+		return LocalDateTime{}, fmt.Errorf("date.ParseLocalDateTime: cannot parse %q: %w", value, err)
+	}
+
+	if !hasTime {
+		return LocalDateTime{date: d}, nil
+	}
+
+	c, err := clock.Parse(cs)
+	if err != nil {
+		return LocalDateTime{}, fmt.Errorf("date.ParseLocalDateTime: cannot parse %q: %w", value, err)
+	}
+
+	if c < clock.Midnight || c > clock.Day {
+		return LocalDateTime{}, fmt.Errorf("date.ParseLocalDateTime: cannot parse %q: the time is out of range", value)
+	}
+
+	return NewLocalDateTime(d, c), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that of
+// String. This also provides JSON encoding as a string.
+func (ldt LocalDateTime) MarshalText() ([]byte, error) {
+	return []byte(ldt.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; see ParseLocalDateTime.
+func (ldt *LocalDateTime) UnmarshalText(data []byte) (err error) {
+	*ldt, err = ParseLocalDateTime(string(data))
+	return err
+}
+
+// Scan parses some value, which can be a time.Time, a string or a []byte. The date
+// and wall-clock time of a time.Time are used, whatever its location; strings are
+// parsed using ParseLocalDateTime.
+//
+// This implements sql.Scanner https://golang.org/pkg/database/sql/#Scanner
+func (ldt *LocalDateTime) Scan(value interface{}) (err error) {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		*ldt = LocalDateTimeAt(v)
+	case []byte:
+		*ldt, err = ParseLocalDateTime(string(v))
+	case string:
+		*ldt, err = ParseLocalDateTime(v)
+	default:
+		err = fmt.Errorf("%T %+v is not a meaningful local date-time", value, value)
+	}
+	return err
+}
+
+// Value converts the value for DB storage in a TIMESTAMP WITHOUT TIME ZONE column. It
+// returns a time.Time in UTC that has the date and clock time of ldt, which database
+// drivers store without conversion.
+//
+// This implements driver.Valuer https://golang.org/pkg/database/sql/driver/#Valuer
+func (ldt LocalDateTime) Value() (driver.Value, error) {
+	return ldt.date.MidnightUTC().Add(ldt.clock.DurationSinceMidnight()), nil
+}
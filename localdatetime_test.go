@@ -0,0 +1,220 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2/clock"
+)
+
+func TestNewLocalDateTime(t *testing.T) {
+	cases := []struct {
+		d    Date
+		c    clock.Clock
+		want string
+	}{
+		{d: New(2024, 3, 31), c: clock.New(2, 30, 0, 0), want: "2024-03-31T02:30:00"},
+		{d: New(2024, 3, 31), c: clock.New(25, 0, 0, 0), want: "2024-04-01T01:00:00"},
+		{d: New(2024, 3, 31), c: clock.Day, want: "2024-04-01T00:00:00"},
+		{d: New(2024, 3, 31), c: -clock.Day, want: "2024-03-30T00:00:00"},
+		{d: New(2024, 3, 31), c: clock.New(-1, 0, 0, 0), want: "2024-03-30T23:00:00"},
+		{d: New(2024, 3, 31), c: clock.New(-49, 0, 0, 0), want: "2024-03-28T23:00:00"},
+		{d: New(2024, 3, 31), c: clock.New(9, 0, 0, 5), want: "2024-03-31T09:00:00.005"},
+		{d: New(2024, 3, 31), c: clock.New(9, 0, 0, 0) + 7, want: "2024-03-31T09:00:00.000000007"},
+		{d: New(12345, 6, 7), c: clock.Noon, want: "+12345-06-07T12:00:00"},
+		{want: "0001-01-01T00:00:00"},
+	}
+
+	for i, c := range cases {
+		ldt := NewLocalDateTime(c.d, c.c)
+		if ldt.String() != c.want {
+			t.Errorf("%d: %s %s got %s, want %s", i, c.d, c.c, ldt, c.want)
+		}
+		if ldt.Clock() < clock.Midnight || ldt.Clock() >= clock.Day {
+			t.Errorf("%d: %s %s got clock %s", i, c.d, c.c, ldt.Clock())
+		}
+	}
+}
+
+func TestLocalDateTimeAt(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	ldt := LocalDateTimeAt(time.Date(2024, 3, 31, 23, 15, 0, 0, time.UTC).In(tokyo))
+	if ldt != NewLocalDateTime(New(2024, 4, 1), clock.New(8, 15, 0, 0)) {
+		t.Errorf("got %s", ldt)
+	}
+}
+
+func TestLocalDateTimeCompare(t *testing.T) {
+	a := MustParseLocalDateTime("2024-03-31T02:30")
+	b := MustParseLocalDateTime("2024-03-31T23:00")
+	c := MustParseLocalDateTime("2024-04-01T00:00")
+
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 || b.Compare(c) != -1 {
+		t.Errorf("unexpected comparison")
+	}
+	if !a.Before(c) || a.After(c) || !c.After(b) || c.Before(b) {
+		t.Errorf("unexpected ordering")
+	}
+	if a.IsZero() || !(LocalDateTime{}).IsZero() {
+		t.Errorf("unexpected IsZero")
+	}
+}
+
+func TestLocalDateTimeArithmetic(t *testing.T) {
+	ldt := MustParseLocalDateTime("2024-01-31T22:30")
+
+	cases := []struct {
+		got  LocalDateTime
+		want string
+	}{
+		{got: ldt.AddDays(1), want: "2024-02-01T22:30:00"},
+		{got: ldt.AddDays(-31), want: "2023-12-31T22:30:00"},
+		{got: ldt.AddDate(0, 1, 0), want: "2024-03-02T22:30:00"},
+		{got: ldt.Add(time.Hour), want: "2024-01-31T23:30:00"},
+		{got: ldt.Add(90 * time.Minute), want: "2024-02-01T00:00:00"},
+		{got: ldt.Add(49 * time.Hour), want: "2024-02-02T23:30:00"},
+		{got: ldt.Add(-23 * time.Hour), want: "2024-01-30T23:30:00"},
+		{got: ldt.Add(-71 * time.Hour), want: "2024-01-28T23:30:00"},
+		{got: ldt.Add(time.Nanosecond), want: "2024-01-31T22:30:00.000000001"},
+	}
+
+	for i, c := range cases {
+		if c.got.String() != c.want {
+			t.Errorf("%d: got %s, want %s", i, c.got, c.want)
+		}
+	}
+
+	later := MustParseLocalDateTime("2024-02-02T01:00")
+	if later.Sub(ldt) != 26*time.Hour+30*time.Minute || ldt.Sub(later) != -26*time.Hour-30*time.Minute {
+		t.Errorf("got %v %v", later.Sub(ldt), ldt.Sub(later))
+	}
+
+	far := NewLocalDateTime(Max(), clock.Noon)
+	if far.Sub(ldt) != math.MaxInt64 || ldt.Sub(far) != math.MinInt64 {
+		t.Errorf("got %v %v", far.Sub(ldt), ldt.Sub(far))
+	}
+}
+
+func TestParseLocalDateTime(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{in: "2024-03-31T02:30", want: "2024-03-31T02:30:00"},
+		{in: "2024-03-31T02:30:15", want: "2024-03-31T02:30:15"},
+		{in: "2024-03-31T02:30:15.5", want: "2024-03-31T02:30:15.500"},
+		{in: "2024-03-31 02:30:15.123456789", want: "2024-03-31T02:30:15.123456789"},
+		{in: "20240331T0230", want: "2024-03-31T02:30:00"},
+		{in: "2024-03-31T24:00", want: "2024-04-01T00:00:00"},
+		{in: "2024-03-31", want: "2024-03-31T00:00:00"},
+		{in: "+12345-06-07T12:00:00", want: "+12345-06-07T12:00:00"},
+		{in: "-0001-12-31T12:00:00", want: "-0001-12-31T12:00:00"},
+	}
+
+	for i, c := range cases {
+		ldt, err := ParseLocalDateTime(c.in)
+		if err != nil || ldt.String() != c.want {
+			t.Errorf("%d: %s got %s %v, want %s", i, c.in, ldt, err, c.want)
+		}
+	}
+
+	for i, in := range []string{"", "2024-03-31T", "2024-03-31T25:00", "2024-03-31T02:30Z", "2024-03-31T02:30+01:00", "2024-xx-01T02:30", "noon"} {
+		_, err := ParseLocalDateTime(in)
+		if err == nil {
+			t.Errorf("%d: %q should not parse", i, in)
+		}
+	}
+}
+
+func TestLocalDateTimeJSON(t *testing.T) {
+	type Appointment struct {
+		At LocalDateTime `json:"at"`
+	}
+
+	b, err := json.Marshal(Appointment{At: MustParseLocalDateTime("2024-03-31T02:30")})
+	if err != nil || string(b) != `{"at":"2024-03-31T02:30:00"}` {
+		t.Errorf("got %s %v", b, err)
+	}
+
+	var a Appointment
+	err = json.Unmarshal([]byte(`{"at":"2024-10-27T01:30:00.250"}`), &a)
+	if err != nil || a.At != NewLocalDateTime(New(2024, 10, 27), clock.New(1, 30, 0, 250)) {
+		t.Errorf("got %s %v", a.At, err)
+	}
+}
+
+func TestLocalDateTimeScanValue(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	want := NewLocalDateTime(New(2024, 3, 31), clock.New(2, 30, 0, 0))
+
+	cases := []interface{}{
+		"2024-03-31 02:30:00",
+		[]byte("2024-03-31T02:30"),
+		time.Date(2024, 3, 31, 2, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 2, 30, 0, 0, newYork),
+	}
+
+	for i, c := range cases {
+		var ldt LocalDateTime
+		err := ldt.Scan(c)
+		if err != nil || ldt != want {
+			t.Errorf("%d: %v got %s %v", i, c, ldt, err)
+		}
+	}
+
+	var v driver.Valuer = want
+	got, err := v.Value()
+	if err != nil || !got.(time.Time).Equal(time.Date(2024, 3, 31, 2, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %v %v", got, err)
+	}
+
+	var ldt LocalDateTime
+	if ldt.Scan(nil) != nil || !ldt.IsZero() || ldt.Scan(int64(1)) == nil {
+		t.Errorf("unexpected scan result")
+	}
+}
+
+func TestLocalDateTimeIn(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+
+	got, err := MustParseLocalDateTime("2024-03-31T02:30").In(london, Strict)
+	if err != nil || got.Format(time.RFC3339) != "2024-03-31T02:30:00+01:00" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	got, err = MustParseLocalDateTime("2024-03-31T01:30").In(london, Resolution{})
+	if err != nil || got.Format(time.RFC3339) != "2024-03-31T02:30:00+01:00" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	_, err = MustParseLocalDateTime("2024-10-27T01:30").In(london, Strict)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestLocalDateTimeConvert(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	got, err := MustParseLocalDateTime("2024-10-27T01:30").Convert(london, tokyo, Resolution{Overlap: OverlapLater})
+	if err != nil || got.String() != "2024-10-27T10:30:00" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	got, err = MustParseLocalDateTime("2024-10-27T01:30").Convert(london, tokyo, Resolution{})
+	if err != nil || got.String() != "2024-10-27T09:30:00" {
+		t.Errorf("got %s %v", got, err)
+	}
+
+	_, err = MustParseLocalDateTime("2024-03-31T01:30").Convert(london, tokyo, Strict)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package openinghours parses and evaluates weekly opening-hours schedules written in the
+// OpenStreetMap opening_hours syntax, such as "Mo-Fr 08:00-18:00; Sa 09:00-13:00; PH off".
+// See https://wiki.openstreetmap.org/wiki/Key:opening_hours
+//
+// The commonly used subset of the syntax is supported: rules separated by ';', each with
+// an optional weekday selector (including PH for public holidays), an optional list of
+// time ranges and an optional "open", "off" or "closed" modifier; and "24/7".
+package openinghours
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2/clock"
+)
+
+// rule is one of the ';'-separated rules in a schedule.
+type rule struct {
+	weekdays [7]bool // indexed by time.Weekday
+	holidays bool    // PH
+	everyDay bool    // there is no weekday selector
+	times    []clock.Range
+	closed   bool
+}
+
+// MustParse is as per Parse except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParse(s string) Schedule {
+	sch, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return sch
+}
+
+// Parse parses a schedule in OpenStreetMap opening_hours syntax, for example
+//
+//	Mo-Fr 08:00-12:00,13:00-17:30; Sa 09:00-13:00; PH off
+//
+// Each rule selects some days and gives the opening times on those days, replacing
+// anything that earlier rules gave for the same days. A rule without a weekday selector
+// applies to every day; a rule without times means open all day, unless it is "off" or
+// "closed". Time ranges that end after midnight, such as "22:00-02:00" or "22:00-26:00",
+// continue into the next day.
+//
+// The holidays are not part of the schedule: they are supplied in Schedule.Holidays.
+func Parse(s string) (Schedule, error) {
+	var rules []rule
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		r, err := parseRule(part)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("openinghours.Parse: cannot parse %q: %w", s, err)
+		}
+		rules = append(rules, r)
+	}
+
+	if len(rules) == 0 {
+		return Schedule{}, fmt.Errorf("openinghours.Parse: cannot parse %q because there are no rules", s)
+	}
+
+	return Schedule{rules: rules}, nil
+}
+
+func parseRule(s string) (rule, error) {
+	if s == "24/7" {
+		return rule{everyDay: true}, nil
+	}
+
+	r := rule{everyDay: true}
+	fields := strings.Fields(closeLists(s))
+
+	if len(fields) > 0 && isWeekdaySelector(fields[0]) {
+		if err := r.parseWeekdays(fields[0]); err != nil {
+			return rule{}, err
+		}
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 && isTimeSelector(fields[0]) {
+		times, err := parseTimes(fields[0])
+		if err != nil {
+			return rule{}, err
+		}
+		r.times = times
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 {
+		switch strings.ToLower(fields[0]) {
+		case "off", "closed":
+			r.closed = true
+			fields = fields[1:]
+		case "open":
+			fields = fields[1:]
+		}
+	}
+
+	if len(fields) > 0 && strings.HasPrefix(fields[0], `"`) {
+		fields = nil // a comment
+	}
+
+	if len(fields) > 0 {
+		return rule{}, fmt.Errorf("unsupported selector %q", fields[0])
+	}
+
+	if r.closed && r.times != nil {
+		return rule{}, fmt.Errorf("closed rules cannot have times in %q", s)
+	}
+
+	return r, nil
+}
+
+// closeLists removes the whitespace around the commas in weekday and time lists, so
+// "Mo-Fr 08:00-12:00, 13:00-17:30" becomes "Mo-Fr 08:00-12:00,13:00-17:30". A quoted
+// comment is left as it is.
+func closeLists(s string) string {
+	selectors, comment := s, ""
+	if i := strings.IndexByte(s, '"'); i >= 0 {
+		selectors, comment = s[:i], s[i:]
+	}
+
+	items := strings.Split(selectors, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+
+	if comment == "" {
+		return strings.Join(items, ",")
+	}
+	return strings.Join(items, ",") + " " + comment
+}
+
+var weekdayNames = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for i, name := range weekdayNames {
+		if s == name {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+func isWeekdaySelector(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	_, ok := parseWeekday(s[:2])
+	return ok || s[:2] == "PH"
+}
+
+func isTimeSelector(s string) bool {
+	return len(s) > 0 && '0' <= s[0] && s[0] <= '9'
+}
+
+// parseWeekdays parses a comma-separated list of weekdays, weekday ranges such as "Mo-Fr"
+// or "Fr-Mo", and PH.
+func (r *rule) parseWeekdays(s string) error {
+	r.everyDay = false
+	for _, item := range strings.Split(s, ",") {
+		if item == "PH" {
+			r.holidays = true
+			continue
+		}
+
+		first, last, isRange := strings.Cut(item, "-")
+		from, ok1 := parseWeekday(first)
+		to, ok2 := from, true
+		if isRange {
+			to, ok2 = parseWeekday(last)
+		}
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid weekday selector %q", item)
+		}
+
+		for d := from; ; d = (d + 1) % 7 {
+			r.weekdays[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// parseTimes parses a comma-separated list of time ranges such as "08:00-12:00,13:00-17:30".
+// The end may be up to 48:00, i.e. the following day.
+func parseTimes(s string) ([]clock.Range, error) {
+	var times []clock.Range
+	for _, item := range strings.Split(s, ",") {
+		first, last, found := strings.Cut(item, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid time range %q", item)
+		}
+
+		start, err1 := parseTime(first)
+		end, err2 := parseTime(last)
+		r := clock.NewRange(start, end)
+		if err1 != nil || err2 != nil || start >= clock.Day || end > start+clock.Day || r.IsEmpty() {
+			return nil, fmt.Errorf("invalid time range %q", item)
+		}
+
+		times = append(times, r)
+	}
+	return times, nil
+}
+
+func parseTime(s string) (clock.Clock, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return clock.Parse(s)
+}
+
+// String formats the rule in opening_hours syntax.
+func (r rule) String() string {
+	var parts []string
+	if !r.everyDay {
+		parts = append(parts, r.formatWeekdays())
+	}
+
+	if len(r.times) > 0 {
+		times := make([]string, len(r.times))
+		for i, t := range r.times {
+			times[i] = t.String()
+		}
+		parts = append(parts, strings.Join(times, ","))
+	}
+
+	if r.closed {
+		parts = append(parts, "off")
+	} else if len(parts) == 0 {
+		return "24/7"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatWeekdays writes the weekdays from Monday to Sunday, as ranges where three or
+// more are consecutive.
+func (r rule) formatWeekdays() string {
+	var items []string
+	for i := 0; i < 7; {
+		if !r.weekdays[(i+1)%7] {
+			i++
+			continue
+		}
+
+		j := i
+		for j+1 < 7 && r.weekdays[(j+2)%7] {
+			j++
+		}
+
+		switch j - i {
+		case 0:
+			items = append(items, weekdayNames[(i+1)%7])
+		case 1:
+			items = append(items, weekdayNames[(i+1)%7], weekdayNames[(j+1)%7])
+		default:
+			items = append(items, weekdayNames[(i+1)%7]+"-"+weekdayNames[(j+1)%7])
+		}
+		i = j + 1
+	}
+
+	if r.holidays {
+		items = append(items, "PH")
+	}
+	return strings.Join(items, ",")
+}
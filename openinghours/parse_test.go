@@ -0,0 +1,99 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package openinghours
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{in: "24/7", want: "24/7"},
+		{in: "Mo-Fr 08:00-18:00; Sa 09:00-13:00; PH off", want: "Mo-Fr 08:00-18:00; Sa 09:00-13:00; PH off"},
+		{in: "Mo-Fr 08:00-12:00,13:00-17:30", want: "Mo-Fr 08:00-12:00,13:00-17:30"},
+		{in: "Mo-Fr 08:00-12:00, 13:00-17:30", want: "Mo-Fr 08:00-12:00,13:00-17:30"},
+		{in: "Mo, We, Fr 10:00-16:00", want: "Mo,We,Fr 10:00-16:00"},
+		{in: "Mo,We,Fr 10:00-16:00", want: "Mo,We,Fr 10:00-16:00"},
+		{in: "Sa,Su 10:00-16:00", want: "Sa,Su 10:00-16:00"},
+		{in: "Fr-Mo 18:00-02:00", want: "Mo,Fr-Su 18:00-02:00"},
+		{in: "Fr-Su 22:00-26:00", want: "Fr-Su 22:00-02:00"},
+		{in: "Mo-Su 00:00-24:00", want: "Mo-Su 00:00-24:00"},
+		{in: "Mo-Fr,PH 09:00-17:00", want: "Mo-Fr,PH 09:00-17:00"},
+		{in: "Mo-Sa; Su closed", want: "Mo-Sa; Su off"},
+		{in: "08:00-20:00; Su off", want: "08:00-20:00; Su off"},
+		{in: "Mo-Fr 09:00-17:00 open", want: "Mo-Fr 09:00-17:00"},
+		{in: `PH off "except by appointment"`, want: "PH off"},
+		{in: `Mo 10:00-12:00 "by appointment, ring bell"`, want: "Mo 10:00-12:00"},
+		{in: `Mo, Tu 10:00-12:00 "by appointment ,ring bell"`, want: "Mo,Tu 10:00-12:00"},
+		{in: " Mo 09:00-10:00 ;; Tu 09:00-10:00; ", want: "Mo 09:00-10:00; Tu 09:00-10:00"},
+	}
+
+	for i, c := range cases {
+		s, err := Parse(c.in)
+		if err != nil || s.String() != c.want {
+			t.Errorf("%d: %s got %q %v, want %q", i, c.in, s, err, c.want)
+		}
+	}
+}
+
+func TestCloseLists(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{in: "Mo, We 08:00-12:00, 13:00-17:30", want: "Mo,We 08:00-12:00,13:00-17:30"},
+		{in: `Mo 10:00-12:00 "by appointment , ring bell"`, want: `Mo 10:00-12:00 "by appointment , ring bell"`},
+		{in: `Mo, Tu 10:00-12:00 "a, b"`, want: `Mo,Tu 10:00-12:00 "a, b"`},
+	}
+
+	for i, c := range cases {
+		if got := closeLists(c.in); got != c.want {
+			t.Errorf("%d: got %q, want %q", i, got, c.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"",
+		";",
+		"Mo-Xx 09:00-17:00",
+		"Mo 9:00-17:00",
+		"Mo 09:00",
+		"Mo 09:00-09:00",
+		"Mo 09:00-49:00",
+		"Mo 08:00-32:00",
+		"Mo 25:00-26:00",
+		"Mo 09:00-17:00 off",
+		"Jan-Mar Mo 09:00-17:00",
+		"Mo 09:00-17:00 unknown",
+		"SH off",
+	}
+
+	for i, in := range cases {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("%d: %q should not parse", i, in)
+		}
+	}
+}
+
+func TestScheduleJSON(t *testing.T) {
+	type Shop struct {
+		Hours Schedule `json:"hours"`
+	}
+
+	var shop Shop
+	err := json.Unmarshal([]byte(`{"hours":"Mo-Fr 08:00-18:00; PH off"}`), &shop)
+	if err != nil || shop.Hours.String() != "Mo-Fr 08:00-18:00; PH off" {
+		t.Errorf("got %s %v", shop.Hours, err)
+	}
+
+	b, err := json.Marshal(shop)
+	if err != nil || string(b) != `{"hours":"Mo-Fr 08:00-18:00; PH off"}` {
+		t.Errorf("got %s %v", b, err)
+	}
+}
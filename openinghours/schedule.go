@@ -0,0 +1,179 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package openinghours
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Holidays decides which dates are public holidays. Both *timespan.DateSet and
+// timespan.DateRangeSet can be used.
+type Holidays interface {
+	Contains(d date.Date) bool
+}
+
+// Schedule is a weekly opening-hours schedule. Use Parse to obtain one.
+//
+// All the methods evaluate the schedule in the location of the times or the location
+// given, so the same schedule can be used for premises in different time zones. Opening
+// times are DST-correct: on the days when the clocks change, the opening times are still
+// the local clock times given in the schedule.
+type Schedule struct {
+	rules []rule
+
+	// Holidays are the dates to which the PH selector applies. If this is nil, there are
+	// no public holidays.
+	Holidays Holidays
+}
+
+// searchDays limits how far ahead NextChange looks for a change.
+const searchDays = 366
+
+// String formats the schedule in opening_hours syntax.
+func (s Schedule) String() string {
+	rules := make([]string, len(s.rules))
+	for i, r := range s.rules {
+		rules[i] = r.String()
+	}
+	return strings.Join(rules, "; ")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that of String.
+// The holidays are not included.
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; see Parse. The holidays
+// are not altered.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	s.rules = parsed.rules
+	return nil
+}
+
+// TimesOn returns the opening times on a date, as given by the last rule that applies to
+// that date. A time range that wraps midnight continues into the following date.
+func (s Schedule) TimesOn(d date.Date) []clock.Range {
+	var times []clock.Range
+	for _, r := range s.rules {
+		if r.appliesTo(d, s.Holidays) {
+			switch {
+			case r.closed:
+				times = nil
+			case r.times == nil:
+				times = []clock.Range{clock.AllDay}
+			default:
+				times = r.times
+			}
+		}
+	}
+	return times
+}
+
+func (r rule) appliesTo(d date.Date, holidays Holidays) bool {
+	switch {
+	case r.everyDay:
+		return true
+	case r.weekdays[d.Weekday()]:
+		return true
+	}
+	return r.holidays && holidays != nil && holidays.Contains(d)
+}
+
+// spansOn returns the open time spans that start on a date.
+func (s Schedule) spansOn(d date.Date, loc *time.Location) []timespan.TimeSpan {
+	var spans []timespan.TimeSpan
+	for _, window := range s.TimesOn(d) {
+		for ts := range timespan.OneDayRange(d).DailySpansIn(window, loc) {
+			spans = append(spans, ts)
+		}
+	}
+	return spans
+}
+
+// openSet returns the open time within a date range, including time on the day before
+// that continues into the range.
+func (s Schedule) openSet(dateRange timespan.DateRange, loc *time.Location) timespan.TimeSpanSet {
+	var spans []timespan.TimeSpan
+	for d := dateRange.Start() - 1; d < dateRange.End(); d++ {
+		spans = append(spans, s.spansOn(d, loc)...)
+	}
+	return timespan.NewTimeSpanSet(spans...).Intersect(timespan.NewTimeSpanSet(dateRange.TimeSpanIn(loc)))
+}
+
+// IsOpen tests whether the schedule is open at a given time. The schedule is evaluated
+// in the location of the time.
+func (s Schedule) IsOpen(t time.Time) bool {
+	return s.openSet(timespan.OneDayRange(date.NewAt(t)), t.Location()).Contains(t)
+}
+
+// OpenSpans lists the times when the schedule is open within a date range, evaluated in
+// a specified location. Adjacent spans are joined, so a span can last for several days,
+// but spans are clipped to the date range.
+func (s Schedule) OpenSpans(dateRange timespan.DateRange, loc *time.Location) []timespan.TimeSpan {
+	return s.openSet(dateRange, loc).Spans()
+}
+
+// NextChange finds the first time after t at which the schedule opens or closes. The
+// schedule is evaluated in the location of t. The flag is false if there is no change
+// within a year.
+func (s Schedule) NextChange(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	const chunk = 14
+
+	for d := date.NewAt(t); d <= date.NewAt(t)+searchDays; d += chunk {
+		// each chunk starts a day early so that a change at its first midnight is seen;
+		// edges at the start or end of a chunk are only where the spans were clipped
+		dateRange := timespan.DayRange(d-1, chunk+1)
+		first, limit := dateRange.StartTimeIn(loc), dateRange.EndTimeIn(loc)
+
+		for _, ts := range s.OpenSpans(dateRange, loc) {
+			for _, edge := range []time.Time{ts.Start(), ts.End()} {
+				if edge.After(t) && edge.After(first) && edge.Before(limit) {
+					return edge, true
+				}
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextOpen finds the next time after t at which the schedule opens. If it is open at t,
+// this is when it reopens after next closing. The flag is false if it does not open
+// within a year.
+func (s Schedule) NextOpen(t time.Time) (time.Time, bool) {
+	return s.nextEdge(t, true)
+}
+
+// NextClose finds the next time after t at which the schedule closes. If it is closed
+// at t, this is when it closes after next opening. The flag is false if it does not close
+// within a year.
+func (s Schedule) NextClose(t time.Time) (time.Time, bool) {
+	return s.nextEdge(t, false)
+}
+
+func (s Schedule) nextEdge(t time.Time, opening bool) (time.Time, bool) {
+	for i := 0; i < 2; i++ {
+		next, ok := s.NextChange(t)
+		if !ok {
+			return time.Time{}, false
+		}
+		if s.IsOpen(next) == opening {
+			return next, true
+		}
+		t = next
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package openinghours
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Monday 25th March 2024
+var mon = date.New(2024, time.March, 25)
+
+func at(d date.Date, hour, minute int) time.Time {
+	y, m, dd := d.Date()
+	return time.Date(y, m, dd, hour, minute, 0, 0, time.UTC)
+}
+
+func formatSpans(spans []timespan.TimeSpan) string {
+	s := make([]string, len(spans))
+	for i, ts := range spans {
+		s[i] = ts.Start().Format("Mon 15:04") + "-" + ts.End().Format("Mon 15:04")
+	}
+	return strings.Join(s, ", ")
+}
+
+func TestIsOpen(t *testing.T) {
+	shop := MustParse("Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-13:00; PH off")
+	shop.Holidays = timespan.NewDateSet(mon + 4) // Good Friday
+	bar := MustParse("Tu-Sa 18:00-02:00")
+
+	cases := []struct {
+		t         time.Time
+		shop, bar bool
+	}{
+		{t: at(mon, 7, 59)},
+		{t: at(mon, 8, 0), shop: true},
+		{t: at(mon, 11, 59), shop: true},
+		{t: at(mon, 12, 0)},
+		{t: at(mon, 13, 0), shop: true},
+		{t: at(mon, 18, 0)},
+		{t: at(mon+1, 1, 0)},
+		{t: at(mon+1, 18, 0), shop: false, bar: true},
+		{t: at(mon+2, 1, 59), shop: false, bar: true},
+		{t: at(mon+2, 2, 0)},
+		{t: at(mon+4, 10, 0), bar: false},
+		{t: at(mon+5, 10, 0), shop: true},
+		{t: at(mon+6, 1, 0), bar: true},
+		{t: at(mon+6, 10, 0)},
+		{t: at(mon+7, 1, 0)},
+	}
+
+	for i, c := range cases {
+		if shop.IsOpen(c.t) != c.shop || bar.IsOpen(c.t) != c.bar {
+			t.Errorf("%d: %s got %v %v", i, c.t.Format("Mon 15:04"), shop.IsOpen(c.t), bar.IsOpen(c.t))
+		}
+	}
+}
+
+func TestTimesOn(t *testing.T) {
+	s := MustParse("Mo-Sa 09:00-17:00; We 09:00-12:00; Su off; PH 10:00-14:00")
+	s.Holidays = timespan.NewDateRangeSet(timespan.OneDayRange(mon + 2))
+
+	cases := []struct {
+		d    date.Date
+		want string
+	}{
+		{d: mon, want: "[09:00-17:00]"},
+		{d: mon + 1, want: "[09:00-17:00]"},
+		{d: mon + 2, want: "[10:00-14:00]"},
+		{d: mon + 9, want: "[09:00-12:00]"},
+		{d: mon + 6, want: "[]"},
+	}
+
+	for i, c := range cases {
+		got := formatRanges(s.TimesOn(c.d))
+		if got != c.want {
+			t.Errorf("%d: %s got %s, want %s", i, c.d, got, c.want)
+		}
+	}
+
+	if formatRanges(MustParse("24/7").TimesOn(mon)) != "[00:00-24:00]" {
+		t.Errorf("24/7 got %v", MustParse("24/7").TimesOn(mon))
+	}
+}
+
+func formatRanges[T interface{ String() string }](rs []T) string {
+	s := make([]string, len(rs))
+	for i, r := range rs {
+		s[i] = r.String()
+	}
+	return "[" + strings.Join(s, " ") + "]"
+}
+
+func TestOpenSpans(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+
+	cases := []struct {
+		schedule string
+		dr       timespan.DateRange
+		loc      *time.Location
+		want     string
+	}{
+		{
+			schedule: "Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-13:00",
+			dr:       timespan.DayRange(mon+4, 3),
+			loc:      time.UTC,
+			want:     "Fri 08:00-Fri 12:00, Fri 13:00-Fri 18:00, Sat 09:00-Sat 13:00",
+		},
+		{
+			// Friday night continues into Saturday, and Saturday night into Sunday
+			schedule: "Tu-Sa 18:00-02:00",
+			dr:       timespan.DayRange(mon+5, 2),
+			loc:      time.UTC,
+			want:     "Sat 00:00-Sat 02:00, Sat 18:00-Sun 02:00",
+		},
+		{
+			// the span that starts on Monday evening is clipped to the range
+			schedule: "Mo 18:00-02:00",
+			dr:       timespan.DayRange(mon+1, 1),
+			loc:      time.UTC,
+			want:     "Tue 00:00-Tue 02:00",
+		},
+		{
+			schedule: "24/7",
+			dr:       timespan.DayRange(mon, 3),
+			loc:      time.UTC,
+			want:     "Mon 00:00-Thu 00:00",
+		},
+		{
+			// the London clocks go forward at 1am on Sunday 31st March 2024
+			schedule: "Sa 22:00-06:00",
+			dr:       timespan.DayRange(mon+5, 2),
+			loc:      london,
+			want:     "Sat 22:00-Sun 06:00",
+		},
+	}
+
+	for i, c := range cases {
+		spans := MustParse(c.schedule).OpenSpans(c.dr, c.loc)
+		got := formatSpans(spans)
+		if got != c.want {
+			t.Errorf("%d: %s got %s, want %s", i, c.schedule, got, c.want)
+		}
+	}
+
+	spans := MustParse("Sa 22:00-06:00").OpenSpans(timespan.DayRange(mon+5, 2), london)
+	if spans[0].Duration() != 7*time.Hour {
+		t.Errorf("got %v", spans[0].Duration())
+	}
+}
+
+func TestNextChange(t *testing.T) {
+	shop := MustParse("Mo-Fr 08:00-12:00,13:00-18:00; Sa 09:00-13:00; PH off")
+	shop.Holidays = timespan.NewDateSet(mon + 4) // Good Friday
+
+	cases := []struct {
+		t                   time.Time
+		change, open, close time.Time
+	}{
+		{t: at(mon, 7, 0), change: at(mon, 8, 0), open: at(mon, 8, 0), close: at(mon, 12, 0)},
+		{t: at(mon, 8, 0), change: at(mon, 12, 0), open: at(mon, 13, 0), close: at(mon, 12, 0)},
+		{t: at(mon, 12, 30), change: at(mon, 13, 0), open: at(mon, 13, 0), close: at(mon, 18, 0)},
+		{t: at(mon+3, 18, 0), change: at(mon+5, 9, 0), open: at(mon+5, 9, 0), close: at(mon+5, 13, 0)},
+		{t: at(mon+5, 14, 0), change: at(mon+7, 8, 0), open: at(mon+7, 8, 0), close: at(mon+7, 12, 0)},
+	}
+
+	for i, c := range cases {
+		change, ok1 := shop.NextChange(c.t)
+		open, ok2 := shop.NextOpen(c.t)
+		closing, ok3 := shop.NextClose(c.t)
+		if !ok1 || !ok2 || !ok3 || !change.Equal(c.change) || !open.Equal(c.open) || !closing.Equal(c.close) {
+			t.Errorf("%d: %s got %s %s %s", i, c.t.Format("Mon 02 15:04"),
+				change.Format("Mon 02 15:04"), open.Format("Mon 02 15:04"), closing.Format("Mon 02 15:04"))
+		}
+	}
+
+	// a change at midnight that falls on the boundary between chunks
+	s := MustParse("Mo-Su 00:00-24:00; We off")
+	for d := mon - 14; d < mon+14; d++ {
+		next, ok := s.NextChange(at(d, 12, 0))
+		want := at(d+1, 0, 0)
+		if d.Weekday() != time.Tuesday && d.Weekday() != time.Wednesday {
+			want = at(d+date.Date((time.Wednesday-d.Weekday()+7)%7), 0, 0)
+		}
+		if !ok || !next.Equal(want) {
+			t.Errorf("%s got %s, want %s", d, next, want)
+		}
+	}
+
+	if _, ok := MustParse("24/7").NextChange(at(mon, 12, 0)); ok {
+		t.Errorf("24/7 should never change")
+	}
+	if _, ok := MustParse("Mo off").NextOpen(at(mon, 12, 0)); ok {
+		t.Errorf("closed should never open")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rickb777/date/v2/clock"
+)
+
+// GapPolicy decides what happens to a wall-clock time that does not exist because the
+// clocks went forward, e.g. 01:30 in London on the last Sunday in March.
+type GapPolicy int
+
+const (
+	// GapShiftForward moves the time forward by the length of the gap, so 01:30 becomes
+	// 02:30 when the clocks go forward by an hour at 01:00.
+	GapShiftForward GapPolicy = iota
+
+	// GapShiftBackward moves the time backward by the length of the gap, so 01:30 becomes
+	// 00:30 when the clocks go forward by an hour at 01:00.
+	GapShiftBackward
+
+	// GapReject gives an error wrapping ErrNonExistent.
+	GapReject
+)
+
+// OverlapPolicy decides what happens to a wall-clock time that occurs twice because the
+// clocks went back, e.g. 01:30 in London on the last Sunday in October.
+type OverlapPolicy int
+
+const (
+	// OverlapEarlier chooses the earlier of the two instants, i.e. the one before the
+	// clocks went back.
+	OverlapEarlier OverlapPolicy = iota
+
+	// OverlapLater chooses the later of the two instants, i.e. the one after the clocks
+	// went back.
+	OverlapLater
+
+	// OverlapReject gives an error wrapping ErrAmbiguous.
+	OverlapReject
+)
+
+// Resolution holds the policies for converting a wall-clock time to an instant when a
+// daylight-saving transition means that the wall-clock time does not exist or exists
+// twice. The zero value shifts non-existent times forward and chooses the earlier of
+// ambiguous times.
+type Resolution struct {
+	Gap     GapPolicy
+	Overlap OverlapPolicy
+}
+
+// Strict is the resolution that rejects both non-existent and ambiguous wall-clock times.
+var Strict = Resolution{Gap: GapReject, Overlap: OverlapReject}
+
+var (
+	// ErrNonExistent is wrapped by the error returned for a wall-clock time that does not
+	// exist in a location when the resolution is GapReject.
+	ErrNonExistent = errors.New("the time does not exist")
+
+	// ErrAmbiguous is wrapped by the error returned for a wall-clock time that exists
+	// twice in a location when the resolution is OverlapReject.
+	ErrAmbiguous = errors.New("the time is ambiguous")
+)
+
+// resolve finds the instant at which the wall-clock time c on date d occurs in loc.
+// The clock may be outside the range 0 to 24 hours, in which case it carries into
+// adjacent days.
+func resolve(d Date, c clock.Clock, loc *time.Location, res Resolution) (time.Time, error) {
+	// the wall-clock time as though it were UTC
+	wall := decode(d).Add(time.Duration(c))
+	secs, nsec := wall.Unix(), int64(wall.Nanosecond())
+
+	// all the offsets that apply within a day either side are candidates; the offset
+	// of the wall time is one for which the instant has that same offset
+	before := offsetAt(secs-secondsPerDay, loc)
+	after := offsetAt(secs+secondsPerDay, loc)
+
+	var valid []int64
+	for _, offset := range []int64{before, offsetAt(secs, loc), after} {
+		instant := secs - offset
+		if offsetAt(instant, loc) == offset && !slices.Contains(valid, instant) {
+			valid = append(valid, instant)
+		}
+	}
+
+	switch len(valid) {
+	case 0:
+		switch res.Gap {
+		case GapShiftBackward:
+			return time.Unix(secs-after, nsec).In(loc), nil
+		case GapReject:
+			return time.Time{}, fmt.Errorf("%w: %s %s in %s", ErrNonExistent, d, c, loc)
+		}
+		return time.Unix(secs-before, nsec).In(loc), nil
+
+	case 1:
+		return time.Unix(valid[0], nsec).In(loc), nil
+	}
+
+	earlier, later := min(valid[0], valid[1]), max(valid[0], valid[1])
+	switch res.Overlap {
+	case OverlapLater:
+		return time.Unix(later, nsec).In(loc), nil
+	case OverlapReject:
+		return time.Time{}, fmt.Errorf("%w: %s %s in %s", ErrAmbiguous, d, c, loc)
+	}
+	return time.Unix(earlier, nsec).In(loc), nil
+}
+
+func offsetAt(secs int64, loc *time.Location) int64 {
+	_, offset := time.Unix(secs, 0).In(loc).Zone()
+	return int64(offset)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package date
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2/clock"
+)
+
+func TestResolve(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	santiago, _ := time.LoadLocation("America/Santiago")
+
+	forwardLater := Resolution{Gap: GapShiftForward, Overlap: OverlapLater}
+	backward := Resolution{Gap: GapShiftBackward}
+
+	cases := []struct {
+		d    Date
+		c    clock.Clock
+		loc  *time.Location
+		res  Resolution
+		want string
+		err  error
+	}{
+		// ordinary times
+		{d: New(2024, 3, 30), c: clock.New(1, 30, 0, 0), loc: london, want: "2024-03-30T01:30:00Z"},
+		{d: New(2024, 7, 1), c: clock.New(12, 0, 0, 0), loc: london, res: Strict, want: "2024-07-01T12:00:00+01:00"},
+		{d: New(2024, 3, 31), c: clock.New(3, 30, 0, 0), loc: london, res: Strict, want: "2024-03-31T03:30:00+01:00"},
+		{d: New(2024, 3, 31), c: clock.New(0, 59, 59, 999), loc: london, res: Strict, want: "2024-03-31T00:59:59.999Z"},
+
+		// London spring forward at 01:00
+		{d: New(2024, 3, 31), c: clock.New(1, 30, 0, 0), loc: london, want: "2024-03-31T02:30:00+01:00"},
+		{d: New(2024, 3, 31), c: clock.New(1, 30, 0, 0), loc: london, res: backward, want: "2024-03-31T00:30:00Z"},
+		{d: New(2024, 3, 31), c: clock.New(1, 30, 0, 0), loc: london, res: Strict, err: ErrNonExistent},
+		{d: New(2024, 3, 31), c: clock.New(1, 0, 0, 0), loc: london, res: Strict, err: ErrNonExistent},
+		{d: New(2024, 3, 31), c: clock.New(2, 0, 0, 0), loc: london, res: Strict, want: "2024-03-31T02:00:00+01:00"},
+
+		// London fall back at 02:00
+		{d: New(2024, 10, 27), c: clock.New(1, 30, 0, 0), loc: london, want: "2024-10-27T01:30:00+01:00"},
+		{d: New(2024, 10, 27), c: clock.New(1, 30, 0, 0), loc: london, res: forwardLater, want: "2024-10-27T01:30:00Z"},
+		{d: New(2024, 10, 27), c: clock.New(1, 30, 0, 0), loc: london, res: Strict, err: ErrAmbiguous},
+		{d: New(2024, 10, 27), c: clock.New(2, 0, 0, 0), loc: london, res: Strict, want: "2024-10-27T02:00:00Z"},
+
+		// Santiago skips midnight in September and repeats the hour before it in April
+		{d: New(2024, 9, 8), c: clock.Midnight, loc: santiago, want: "2024-09-08T01:00:00-03:00"},
+		{d: New(2024, 9, 8), c: clock.Midnight, loc: santiago, res: backward, want: "2024-09-07T23:00:00-04:00"},
+		{d: New(2024, 9, 8), c: clock.New(0, 30, 0, 0), loc: santiago, res: Strict, err: ErrNonExistent},
+		{d: New(2024, 4, 6), c: clock.New(23, 30, 0, 0), loc: santiago, want: "2024-04-06T23:30:00-03:00"},
+		{d: New(2024, 4, 6), c: clock.New(23, 30, 0, 0), loc: santiago, res: forwardLater, want: "2024-04-06T23:30:00-04:00"},
+
+		// clocks beyond one day carry into the next
+		{d: New(2024, 3, 30), c: clock.New(25, 30, 0, 0), loc: london, want: "2024-03-31T02:30:00+01:00"},
+		{d: New(2024, 4, 1), c: clock.New(-1, 0, 0, 0), loc: time.UTC, want: "2024-03-31T23:00:00Z"},
+	}
+
+	for i, c := range cases {
+		got, err := resolve(c.d, c.c, c.loc, c.res)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf("%d: %s %s got %v %v, want %v", i, c.d, c.c, got, err, c.err)
+			}
+			continue
+		}
+		if err != nil || got.Format(time.RFC3339Nano) != c.want {
+			t.Errorf("%d: %s %s got %s %v, want %s", i, c.d, c.c, got.Format(time.RFC3339Nano), err, c.want)
+		}
+		if got.Location() != c.loc {
+			t.Errorf("%d: %s %s got location %s", i, c.d, c.c, got.Location())
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/date/v2/gregorian"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// maxEmptyPeriods limits the search for the next occurrence of rules that can never
+// (or only very rarely) match, such as "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30".
+const maxEmptyPeriods = 10000
+
+// DateIterator lazily yields a sequence of dates in ascending order.
+type DateIterator struct {
+	next func() (date.Date, bool)
+}
+
+// Next returns the next date. The flag is false when there are no more dates.
+func (it *DateIterator) Next() (date.Date, bool) {
+	return it.next()
+}
+
+// Take returns up to n of the remaining dates. This is useful for infinite rules.
+func (it *DateIterator) Take(n int) []date.Date {
+	var list []date.Date
+	for len(list) < n {
+		d, ok := it.Next()
+		if !ok {
+			break
+		}
+		list = append(list, d)
+	}
+	return list
+}
+
+// TimeSpanIterator lazily yields a sequence of time spans in ascending order of their
+// start times.
+type TimeSpanIterator struct {
+	next func() (timespan.TimeSpan, bool)
+}
+
+// Next returns the next time span. The flag is false when there are no more time spans.
+func (it *TimeSpanIterator) Next() (timespan.TimeSpan, bool) {
+	return it.next()
+}
+
+// Take returns up to n of the remaining time spans. This is useful for infinite rules.
+func (it *TimeSpanIterator) Take(n int) []timespan.TimeSpan {
+	var list []timespan.TimeSpan
+	for len(list) < n {
+		ts, ok := it.Next()
+		if !ok {
+			break
+		}
+		list = append(list, ts)
+	}
+	return list
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Dates expands the rule into all-day occurrences, starting from dtstart. The dtstart
+// date is included only if it matches the rule; the defaults for absent BYxxx rule parts
+// are taken from dtstart as specified by RFC5545, so usually it does match.
+//
+// UNTIL is compared with the date of Until in its own location.
+func (r Rule) Dates(dtstart date.Date) *DateIterator {
+	until := date.Max()
+	if !r.Until.IsZero() {
+		until = date.NewAt(r.Until)
+	}
+	return &DateIterator{next: newExpander(r, dtstart, until).next}
+}
+
+// TimeSpans expands the rule into timed occurrences. The first occurrence provides
+// the start date, the time of day, the location and the duration of every occurrence.
+// The first occurrence is included only if it matches the rule.
+//
+// Each occurrence has the same wall-clock start time in the location of the first
+// occurrence, so its offset from UTC follows daylight-saving changes. If the start
+// time does not exist on some day (because clocks go forward), it is moved forward by
+// the length of the gap; if it exists twice, the earlier instant is used. This is as
+// per date.Date.Time.
+//
+// A date Until is compared with the date of each occurrence. A floating Until is
+// resolved in the location of the first occurrence, as per date.Date.Time; any other
+// Until is an instant.
+func (r Rule) TimeSpans(first timespan.TimeSpan) *TimeSpanIterator {
+	first = first.Normalise()
+	start := first.Start()
+	loc := start.Location()
+	dtstart := date.NewAt(start)
+	cl := clock.NewAt(start)
+
+	until, untilTime := date.Max(), r.Until
+	switch {
+	case r.Until.IsZero():
+	case r.UntilIsDate:
+		until = date.NewAt(r.Until)
+	case r.UntilIsFloating:
+		until = date.NewAt(r.Until)
+		untilTime = until.Time(clock.NewAt(r.Until), loc)
+	default:
+		until = date.NewAt(r.Until.In(loc))
+	}
+
+	exp := newExpander(r, dtstart, until)
+	done := false
+
+	return &TimeSpanIterator{next: func() (timespan.TimeSpan, bool) {
+		if done {
+			return timespan.TimeSpan{}, false
+		}
+
+		d, ok := exp.next()
+		if !ok {
+			done = true
+			return timespan.TimeSpan{}, false
+		}
+
+		t := d.Time(cl, loc)
+		if !r.Until.IsZero() && !r.UntilIsDate && t.After(untilTime) {
+			done = true
+			return timespan.TimeSpan{}, false
+		}
+
+		return timespan.TimeSpanOf(t, first.Duration()), true
+	}}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+type expander struct {
+	rule    Rule
+	dtstart date.Date
+	until   date.Date
+	period  int // index of the next period to expand
+	emitted int
+	buf     []date.Date
+	done    bool
+}
+
+func newExpander(r Rule, dtstart, until date.Date) *expander {
+	// copy the slices that may be altered below
+	r.ByMonth = slices.Clone(r.ByMonth)
+	r.ByMonthDay = slices.Clone(r.ByMonthDay)
+	r.ByDay = slices.Clone(r.ByDay)
+
+	if len(r.ByWeekNo)+len(r.ByYearDay)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		// apply the defaults from dtstart
+		switch r.Freq {
+		case Yearly:
+			if len(r.ByMonth) == 0 {
+				r.ByMonth = []time.Month{dtstart.Month()}
+			}
+			r.ByMonthDay = []int{dtstart.Day()}
+		case Monthly:
+			r.ByMonthDay = []int{dtstart.Day()}
+		case Weekly:
+			r.ByDay = []WeekdayNum{{Day: dtstart.Weekday()}}
+		}
+	}
+
+	return &expander{rule: r, dtstart: dtstart, until: until, done: r.Validate() != nil}
+}
+
+func (e *expander) next() (date.Date, bool) {
+	empty := 0
+	for !e.done {
+		if len(e.buf) > 0 {
+			d := e.buf[0]
+			e.buf = e.buf[1:]
+
+			if d < e.dtstart {
+				continue
+			}
+
+			if d > e.until || (e.rule.Count > 0 && e.emitted >= e.rule.Count) {
+				e.done = true
+				break
+			}
+
+			e.emitted++
+			return d, true
+		}
+
+		if empty > maxEmptyPeriods {
+			e.done = true
+			break
+		}
+
+		first, candidates := e.candidates(e.period)
+		if first > e.until {
+			e.done = true
+			break
+		}
+
+		e.buf = e.filter(candidates)
+		e.period++
+		if len(e.buf) == 0 {
+			empty++
+		}
+	}
+	return 0, false
+}
+
+// candidates gets the first day of the k'th period and all the days in it.
+func (e *expander) candidates(k int) (date.Date, []date.Date) {
+	r := e.rule
+	step := k * r.interval()
+
+	switch r.Freq {
+	case Yearly:
+		year := e.dtstart.Year() + step
+		first := date.New(year, time.January, 1)
+		if len(r.ByWeekNo) > 0 {
+			return first, weekNoDays(year, r.ByWeekNo, r.WeekStart)
+		}
+		return first, daysBetween(first, date.New(year+1, time.January, 1))
+
+	case Monthly:
+		first := date.New(e.dtstart.Year(), e.dtstart.Month()+time.Month(step), 1)
+		return first, daysBetween(first, first+date.Date(first.LastDayOfMonth()))
+
+	case Weekly:
+		first := weekStart(e.dtstart, r.WeekStart) + date.Date(7*step)
+		return first, daysBetween(first, first+7)
+	}
+
+	first := e.dtstart + date.Date(step)
+	return first, []date.Date{first}
+}
+
+func (e *expander) filter(candidates []date.Date) []date.Date {
+	matched := candidates[:0]
+	for _, d := range candidates {
+		if e.matches(d) {
+			matched = append(matched, d)
+		}
+	}
+
+	if len(e.rule.BySetPos) == 0 || len(matched) == 0 {
+		return matched
+	}
+
+	var selected []date.Date
+	for _, pos := range e.rule.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(matched) + pos
+		}
+		if 0 <= i && i < len(matched) {
+			selected = append(selected, matched[i])
+		}
+	}
+	slices.Sort(selected)
+	return slices.Compact(selected)
+}
+
+func (e *expander) matches(d date.Date) bool {
+	r := e.rule
+
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, d.Month()) {
+		return false
+	}
+
+	if len(r.ByYearDay) > 0 && !matchesOrdinal(r.ByYearDay, d.YearDay(), gregorian.DaysInYear(d.Year())) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 && !matchesOrdinal(r.ByMonthDay, d.Day(), d.LastDayOfMonth()) {
+		return false
+	}
+
+	if len(r.ByDay) > 0 {
+		inMonth := r.Freq == Monthly || (r.Freq == Yearly && len(r.ByMonth) > 0)
+		for _, wn := range r.ByDay {
+			if d.Weekday() == wn.Day && (wn.N == 0 || matchesWeekdayOrdinal(d, wn.N, inMonth)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// matchesOrdinal tests whether a one-based position n within a set of size
+// size matches any of the values, which count backwards from the end when negative.
+func matchesOrdinal(values []int, n, size int) bool {
+	for _, v := range values {
+		if v == n || v == n-size-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekdayOrdinal tests whether d is the n'th such weekday in its month or year.
+func matchesWeekdayOrdinal(d date.Date, n int, inMonth bool) bool {
+	pos, size := d.YearDay(), gregorian.DaysInYear(d.Year())
+	if inMonth {
+		pos, size = d.Day(), d.LastDayOfMonth()
+	}
+
+	if n > 0 {
+		return (pos-1)/7+1 == n
+	}
+	return -((size-pos)/7 + 1) == n
+}
+
+func daysBetween(from, to date.Date) []date.Date {
+	days := make([]date.Date, 0, to-from)
+	for d := from; d < to; d++ {
+		days = append(days, d)
+	}
+	return days
+}
+
+// weekStart gets the start of the week containing d.
+func weekStart(d date.Date, wkst time.Weekday) date.Date {
+	return d - date.Date((int(d.Weekday())-int(wkst)+7)%7)
+}
+
+// firstWeek gets the start of week 1 of a year, which is the first week containing
+// at least four days of the year.
+func firstWeek(year int, wkst time.Weekday) date.Date {
+	jan1 := date.New(year, time.January, 1)
+	ws := weekStart(jan1, wkst)
+	if jan1-ws > 3 {
+		return ws + 7
+	}
+	return ws
+}
+
+// weekNoDays gets all the days in the specified weeks of a year. The first and last
+// weeks can include days from adjacent years.
+func weekNoDays(year int, weekNos []int, wkst time.Weekday) []date.Date {
+	week1 := firstWeek(year, wkst)
+	nWeeks := int(firstWeek(year+1, wkst)-week1) / 7
+
+	var days []date.Date
+	for _, wn := range weekNos {
+		if wn < 0 {
+			wn = nWeeks + wn + 1
+		}
+		if 1 <= wn && wn <= nWeeks {
+			ws := week1 + date.Date(7*(wn-1))
+			days = append(days, daysBetween(ws, ws+7)...)
+		}
+	}
+
+	slices.Sort(days)
+	return slices.Compact(days)
+}
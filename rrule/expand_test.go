@@ -0,0 +1,195 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Most of these cases are examples from RFC5545 section 3.8.5.3.
+func TestDates(t *testing.T) {
+	cases := []struct {
+		rule    string
+		dtstart string
+		n       int
+		exp     string
+	}{
+		{rule: "FREQ=DAILY;COUNT=3", dtstart: "1997-09-02", n: 10,
+			exp: "1997-09-02 1997-09-03 1997-09-04"},
+		{rule: "FREQ=DAILY;UNTIL=19970905", dtstart: "1997-09-02", n: 10,
+			exp: "1997-09-02 1997-09-03 1997-09-04 1997-09-05"},
+		{rule: "FREQ=DAILY;INTERVAL=10;COUNT=5", dtstart: "1997-09-02", n: 10,
+			exp: "1997-09-02 1997-09-12 1997-09-22 1997-10-02 1997-10-12"},
+		{rule: "FREQ=WEEKLY;COUNT=3", dtstart: "1997-09-02", n: 10,
+			exp: "1997-09-02 1997-09-09 1997-09-16"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=TU,TH", dtstart: "1997-09-02", n: 6,
+			exp: "1997-09-02 1997-09-04 1997-09-16 1997-09-18 1997-09-30 1997-10-02"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", dtstart: "1997-08-05", n: 10,
+			exp: "1997-08-05 1997-08-10 1997-08-19 1997-08-24"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", dtstart: "1997-08-05", n: 10,
+			exp: "1997-08-05 1997-08-17 1997-08-19 1997-08-31"},
+		{rule: "FREQ=MONTHLY;COUNT=6;BYDAY=1FR", dtstart: "1997-09-05", n: 10,
+			exp: "1997-09-05 1997-10-03 1997-11-07 1997-12-05 1998-01-02 1998-02-06"},
+		{rule: "FREQ=MONTHLY;INTERVAL=2;COUNT=6;BYDAY=1SU,-1SU", dtstart: "1997-09-07", n: 10,
+			exp: "1997-09-07 1997-09-28 1997-11-02 1997-11-30 1998-01-04 1998-01-25"},
+		{rule: "FREQ=MONTHLY;COUNT=4;BYDAY=-2MO", dtstart: "1997-09-22", n: 10,
+			exp: "1997-09-22 1997-10-20 1997-11-17 1997-12-22"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-3", dtstart: "1997-09-28", n: 4,
+			exp: "1997-09-28 1997-10-29 1997-11-28 1997-12-29"},
+		{rule: "FREQ=MONTHLY;COUNT=5;BYMONTHDAY=2,15", dtstart: "1997-09-02", n: 10,
+			exp: "1997-09-02 1997-09-15 1997-10-02 1997-10-15 1997-11-02"},
+		{rule: "FREQ=MONTHLY", dtstart: "2024-01-31", n: 4,
+			exp: "2024-01-31 2024-03-31 2024-05-31 2024-07-31"},
+		{rule: "FREQ=YEARLY;COUNT=4;BYMONTH=6,7", dtstart: "1997-06-10", n: 10,
+			exp: "1997-06-10 1997-07-10 1998-06-10 1998-07-10"},
+		{rule: "FREQ=YEARLY;INTERVAL=3;COUNT=5;BYYEARDAY=1,100,200", dtstart: "1997-01-01", n: 10,
+			exp: "1997-01-01 1997-04-10 1997-07-19 2000-01-01 2000-04-09"},
+		{rule: "FREQ=YEARLY;BYDAY=20MO", dtstart: "1997-05-19", n: 3,
+			exp: "1997-05-19 1998-05-18 1999-05-17"},
+		{rule: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", dtstart: "1997-05-12", n: 3,
+			exp: "1997-05-12 1998-05-11 1999-05-17"},
+		{rule: "FREQ=YEARLY;BYMONTH=3;BYDAY=TH", dtstart: "1997-03-13", n: 4,
+			exp: "1997-03-13 1997-03-20 1997-03-27 1998-03-05"},
+		{rule: "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", dtstart: "1997-09-02", n: 3,
+			exp: "1998-02-13 1998-03-13 1998-11-13"},
+		{rule: "FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8", dtstart: "1996-11-05", n: 3,
+			exp: "1996-11-05 2000-11-07 2004-11-02"},
+		{rule: "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3", dtstart: "1997-09-04", n: 10,
+			exp: "1997-09-04 1997-10-07 1997-11-06"},
+		{rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", dtstart: "1997-09-29", n: 3,
+			exp: "1997-09-29 1997-10-30 1997-11-27"},
+		{rule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29", dtstart: "2024-02-29", n: 3,
+			exp: "2024-02-29 2028-02-29 2032-02-29"},
+		{rule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", dtstart: "2024-01-01", n: 3,
+			exp: ""},
+		{rule: "FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO", dtstart: "2024-12-30", n: 2,
+			exp: "2024-12-30 2025-12-29"},
+		{rule: "FREQ=YEARLY;BYWEEKNO=-1;BYDAY=SU", dtstart: "2020-01-01", n: 2,
+			exp: "2021-01-03 2022-01-02"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s", i, c.rule), func(t *testing.T) {
+			r := MustParse(c.rule)
+			got := join(r.Dates(date.MustParseISO(c.dtstart)).Take(c.n))
+			if got != c.exp {
+				t.Errorf("%d: %s\n got %s\nwant %s", i, c.rule, got, c.exp)
+			}
+		})
+	}
+}
+
+func TestDatesInfinite(t *testing.T) {
+	it := MustParse("FREQ=DAILY").Dates(date.New(2024, time.January, 1))
+	for i := 0; i < 1000; i++ {
+		if _, ok := it.Next(); !ok {
+			t.Fatalf("%d: infinite rule ended", i)
+		}
+	}
+	d, _ := it.Next()
+	if d != date.New(2024, time.January, 1)+1000 {
+		t.Errorf("got %s", d)
+	}
+}
+
+func TestDatesInvalidRule(t *testing.T) {
+	it := Rule{Freq: Weekly, ByMonthDay: []int{1}}.Dates(date.New(2024, time.January, 1))
+	if _, ok := it.Next(); ok {
+		t.Errorf("an invalid rule should yield nothing")
+	}
+}
+
+func TestTimeSpans(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	start := time.Date(2024, time.March, 29, 9, 30, 0, 0, london)
+	first := timespan.TimeSpanOf(start, time.Hour)
+
+	spans := MustParse("FREQ=DAILY;COUNT=3").TimeSpans(first).Take(10)
+	exp := []string{
+		"20240329T093000/20240329T103000",
+		"20240330T093000/20240330T103000",
+		"20240331T093000/20240331T103000", // BST started on this day
+	}
+	if len(spans) != len(exp) {
+		t.Fatalf("got %v", spans)
+	}
+	for i, ts := range spans {
+		if ts.Format("", "/", false) != exp[i] || ts.Start().Location() != london {
+			t.Errorf("%d: got %s", i, ts.Format("", "/", false))
+		}
+	}
+	if spans[2].Start().Sub(spans[1].Start()) != 23*time.Hour {
+		t.Errorf("expected a 23 hour gap across the daylight-saving change")
+	}
+
+	// UNTIL is inclusive and is an instant
+	until := MustParse("FREQ=DAILY;UNTIL=20240331T083000Z").TimeSpans(first).Take(10)
+	if len(until) != 3 {
+		t.Errorf("got %v", until)
+	}
+	until = MustParse("FREQ=DAILY;UNTIL=20240331T082959Z").TimeSpans(first).Take(10)
+	if len(until) != 2 {
+		t.Errorf("got %v", until)
+	}
+	until = MustParse("FREQ=DAILY;UNTIL=20240330").TimeSpans(first).Take(10)
+	if len(until) != 2 {
+		t.Errorf("got %v", until)
+	}
+}
+
+func TestTimeSpansFloatingUntil(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	first := timespan.TimeSpanOf(time.Date(2024, time.January, 1, 9, 0, 0, 0, london), time.Hour)
+
+	// the result must not depend on the local time zone
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	for _, local := range []*time.Location{time.UTC, tokyo, london} {
+		time.Local = local
+		spans := MustParse("FREQ=DAILY;UNTIL=20240103T090000").TimeSpans(first).Take(10)
+		if len(spans) != 3 {
+			t.Errorf("%s: got %v", local, spans)
+		}
+	}
+
+	// in a set, UNTIL without a zone is in the given location, here 00:00Z on 3rd January
+	s := MustParseSet("RRULE:FREQ=DAILY;UNTIL=20240103T090000", tokyo)
+	spans := s.TimeSpans(first).Take(10)
+	if len(spans) != 2 {
+		t.Errorf("got %v", spans)
+	}
+}
+
+func TestTimeSpansAcrossTransitions(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+
+	// 01:30 does not exist on 31st March, so it moves forward to 02:30 BST
+	first := timespan.TimeSpanOf(time.Date(2024, time.March, 30, 1, 30, 0, 0, london), time.Hour)
+	spans := MustParse("FREQ=DAILY;COUNT=2").TimeSpans(first).Take(10)
+	if len(spans) != 2 || !spans[1].Start().Equal(time.Date(2024, time.March, 31, 1, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %v", spans)
+	}
+
+	// 01:30 exists twice on 27th October, so the earlier, 01:30 BST, is used
+	first = timespan.TimeSpanOf(time.Date(2024, time.October, 26, 1, 30, 0, 0, london), time.Hour)
+	spans = MustParse("FREQ=DAILY;COUNT=2").TimeSpans(first).Take(10)
+	if len(spans) != 2 || !spans[1].Start().Equal(time.Date(2024, time.October, 27, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("got %v", spans)
+	}
+}
+
+func join(dates []date.Date) string {
+	s := make([]string, len(dates))
+	for i, d := range dates {
+		s[i] = d.String()
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// MustParse is as per Parse except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParse(s string) Rule {
+	r, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Parse parses an RFC5545 recurrence rule such as "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6".
+// An "RRULE:" prefix is permitted. The rule parts can be in any order; they are
+// case-insensitive.
+//
+// An UNTIL value in the form "20060102T150405" (i.e. without a zone) is a floating
+// wall-clock time, which is resolved in the location of the first occurrence when the
+// rule is expanded (see Rule.UntilIsFloating); the "Z" suffix indicates UTC. The rule
+// is validated using Rule.Validate.
+func Parse(s string) (Rule, error) {
+	return parse(s, nil)
+}
+
+// parse parses a rule; an UNTIL value without a zone is in loc, or floating if loc is nil.
+func parse(s string, loc *time.Location) (Rule, error) {
+	text := strings.TrimSpace(s)
+	if len(text) >= 6 && strings.EqualFold(text[:6], "RRULE:") {
+		text = text[6:]
+	}
+
+	r := NewRule(Yearly)
+	hasFreq := false
+
+	for _, part := range strings.Split(text, ";") {
+		if part == "" {
+			continue
+		}
+
+		eq := strings.IndexByte(part, '=')
+		if eq < 0 {
+			return Rule{}, fmt.Errorf("rrule.Parse: cannot parse %q: missing '=' in %q", s, part)
+		}
+
+		name := strings.ToUpper(part[:eq])
+		value := strings.ToUpper(part[eq+1:])
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq, err = parseFrequency(value)
+			hasFreq = true
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			if loc == nil {
+				r.Until, r.UntilIsDate, err = parseDateOrDateTime(value, time.UTC)
+				r.UntilIsFloating = !r.UntilIsDate && !strings.HasSuffix(value, "Z")
+			} else {
+				r.Until, r.UntilIsDate, err = parseDateOrDateTime(value, loc)
+			}
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(value)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseInts(value)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseInts(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value)
+		case "BYDAY":
+			r.ByDay, err = parseWeekdayNums(value)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value)
+		case "WKST":
+			r.WeekStart, err = parseWeekday(value)
+		case "BYHOUR", "BYMINUTE", "BYSECOND":
+			err = fmt.Errorf("%s is not supported", name)
+		default:
+			err = fmt.Errorf("unknown rule part %s", name)
+		}
+
+		if err != nil {
+			return Rule{}, fmt.Errorf("rrule.Parse: cannot parse %q: %w", s, err)
+		}
+	}
+
+	if !hasFreq {
+		return Rule{}, fmt.Errorf("rrule.Parse: cannot parse %q: FREQ is required", s)
+	}
+
+	if err := r.Validate(); err != nil {
+		return Rule{}, fmt.Errorf("rrule.Parse: %q is invalid: %w", s, err)
+	}
+
+	return r, nil
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	for i, name := range frequencyNames {
+		if value == name {
+			return Frequency(i), nil
+		}
+	}
+	switch value {
+	case "HOURLY", "MINUTELY", "SECONDLY":
+		return 0, fmt.Errorf("frequency %s is not supported", value)
+	}
+	return 0, fmt.Errorf("unknown frequency %s", value)
+}
+
+func parseInts(value string) ([]int, error) {
+	fields := strings.Split(value, ",")
+	ints := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", f)
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for i, code := range weekdayCodes {
+		if value == code {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+func parseWeekdayNums(value string) ([]WeekdayNum, error) {
+	fields := strings.Split(value, ",")
+	wns := make([]WeekdayNum, len(fields))
+	for i, f := range fields {
+		if len(f) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", f)
+		}
+
+		wd, err := parseWeekday(f[len(f)-2:])
+		if err != nil {
+			return nil, err
+		}
+
+		n := 0
+		if len(f) > 2 {
+			n, err = strconv.Atoi(f[:len(f)-2])
+			if err != nil || n == 0 {
+				return nil, fmt.Errorf("invalid weekday ordinal %q", f)
+			}
+		}
+
+		wns[i] = WeekdayNum{N: n, Day: wd}
+	}
+	return wns, nil
+}
+
+// parseDateOrDateTime parses an RFC5545 DATE ("20060102") or DATE-TIME ("20060102T150405"
+// with optional "Z"). A DATE is returned as midnight UTC.
+func parseDateOrDateTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(timespan.RFC5545DateTimeLayout, value[:len(value)-1])
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation(timespan.RFC5545DateTimeLayout, value, loc)
+	return t, false, err
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String formats the rule in RFC5545 form, without the "RRULE:" prefix. The rule parts are
+// written in a conventional order. WKST is omitted when it is Monday (the default).
+func (r Rule) String() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "FREQ=%s", r.Freq)
+
+	if r.Interval > 1 {
+		fmt.Fprintf(buf, ";INTERVAL=%d", r.Interval)
+	}
+
+	if r.Count > 0 {
+		fmt.Fprintf(buf, ";COUNT=%d", r.Count)
+	}
+
+	// as per RFC5545, an UNTIL date-time that is not floating is written in UTC
+	switch {
+	case r.Until.IsZero():
+	case r.UntilIsDate:
+		fmt.Fprintf(buf, ";UNTIL=%s", formatDateOrDateTime(r.Until, true))
+	case r.UntilIsFloating:
+		fmt.Fprintf(buf, ";UNTIL=%s", r.Until.Format(timespan.RFC5545DateTimeLayout))
+	default:
+		fmt.Fprintf(buf, ";UNTIL=%s", r.Until.UTC().Format(timespan.RFC5545DateTimeZulu))
+	}
+
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		writeInts(buf, "BYMONTH", months)
+	}
+
+	writeInts(buf, "BYWEEKNO", r.ByWeekNo)
+	writeInts(buf, "BYYEARDAY", r.ByYearDay)
+	writeInts(buf, "BYMONTHDAY", r.ByMonthDay)
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wn := range r.ByDay {
+			days[i] = wn.String()
+		}
+		fmt.Fprintf(buf, ";BYDAY=%s", strings.Join(days, ","))
+	}
+
+	writeInts(buf, "BYSETPOS", r.BySetPos)
+
+	if r.WeekStart != time.Monday {
+		fmt.Fprintf(buf, ";WKST=%s", weekdayCodes[r.WeekStart])
+	}
+
+	return buf.String()
+}
+
+func writeInts(buf *strings.Builder, name string, values []int) {
+	if len(values) == 0 {
+		return
+	}
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	fmt.Fprintf(buf, ";%s=%s", name, strings.Join(s, ","))
+}
+
+// formatDateOrDateTime formats an RFC5545 DATE or DATE-TIME. Times in UTC have the "Z" suffix;
+// others are formatted as local (floating) time in their own location.
+func formatDateOrDateTime(t time.Time, isDate bool) string {
+	if isDate {
+		return t.Format("20060102")
+	}
+	if t.Location() == time.UTC {
+		return t.Format(timespan.RFC5545DateTimeZulu)
+	}
+	return t.Format(timespan.RFC5545DateTimeLayout)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Rule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Rule) UnmarshalText(data []byte) error {
+	u, err := Parse(string(data))
+	if err == nil {
+		*r = u
+	}
+	return err
+}
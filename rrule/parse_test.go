@@ -0,0 +1,130 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseAndString(t *testing.T) {
+	cases := []struct {
+		in, exp string
+	}{
+		{in: "FREQ=DAILY", exp: "FREQ=DAILY"},
+		{in: "RRULE:FREQ=DAILY;COUNT=10", exp: "FREQ=DAILY;COUNT=10"},
+		{in: "freq=weekly;interval=2;wkst=su;byday=tu,th", exp: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU"},
+		{in: "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20241231", exp: "FREQ=MONTHLY;UNTIL=20241231;BYDAY=-1FR"},
+		{in: "FREQ=YEARLY;UNTIL=20241231T120000Z;BYMONTH=1,2", exp: "FREQ=YEARLY;UNTIL=20241231T120000Z;BYMONTH=1,2"},
+		{in: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO", exp: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO"},
+		{in: "FREQ=YEARLY;BYYEARDAY=1,100,-1", exp: "FREQ=YEARLY;BYYEARDAY=1,100,-1"},
+		{in: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", exp: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2"},
+		{in: "FREQ=MONTHLY;BYMONTHDAY=+2,-3", exp: "FREQ=MONTHLY;BYMONTHDAY=2,-3"},
+		{in: "FREQ=MONTHLY;BYDAY=+1SU", exp: "FREQ=MONTHLY;BYDAY=1SU"},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s", i, c.in), func(t *testing.T) {
+			r, err := Parse(c.in)
+			if err != nil {
+				t.Fatalf("%d: %v", i, err)
+			}
+			if r.String() != c.exp {
+				t.Errorf("%d: got %s, want %s", i, r, c.exp)
+			}
+
+			var r2 Rule
+			b, _ := r.MarshalText()
+			if err = r2.UnmarshalText(b); err != nil {
+				t.Fatalf("%d: %v", i, err)
+			}
+			if r2.String() != c.exp {
+				t.Errorf("%d: got %s, want %s", i, r2, c.exp)
+			}
+		})
+	}
+}
+
+func TestParseFloatingUntil(t *testing.T) {
+	r := MustParse("FREQ=DAILY;UNTIL=20241231T120000")
+	if !r.UntilIsFloating || r.UntilIsDate || r.Until != time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC) {
+		t.Errorf("got %v", r.Until)
+	}
+	if r.String() != "FREQ=DAILY;UNTIL=20241231T120000" {
+		t.Errorf("got %s", r)
+	}
+
+	r = MustParse("FREQ=DAILY;UNTIL=20241231T120000Z")
+	if r.UntilIsFloating {
+		t.Errorf("got %v", r.Until)
+	}
+}
+
+func TestZonedUntilRoundTrip(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	// a zoned Until is written in UTC, so the instant is kept
+	r := NewRule(Daily)
+	r.Until = time.Date(2024, 12, 31, 21, 0, 0, 0, tokyo)
+	if r.String() != "FREQ=DAILY;UNTIL=20241231T120000Z" {
+		t.Errorf("got %s", r)
+	}
+
+	r2 := MustParse(r.String())
+	if !r2.Until.Equal(r.Until) || r2.UntilIsFloating {
+		t.Errorf("got %v", r2.Until)
+	}
+
+	// as is one that has been converted to another location
+	r = MustParse("FREQ=DAILY;UNTIL=20241231T120000Z")
+	r.Until = r.Until.In(tokyo)
+	if r.String() != "FREQ=DAILY;UNTIL=20241231T120000Z" {
+		t.Errorf("got %s", r)
+	}
+
+	// and one parsed in a set location
+	s := MustParseSet("RRULE:FREQ=DAILY;UNTIL=20241231T210000", tokyo)
+	if s.RRules[0].String() != "FREQ=DAILY;UNTIL=20241231T120000Z" {
+		t.Errorf("got %s", s.RRules[0])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"COUNT=1",
+		"FREQ",
+		"FREQ=HOURLY",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;COLOUR=red",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=DAILY;UNTIL=2024",
+		"FREQ=DAILY;BYMONTH=a",
+		"FREQ=DAILY;BYDAY=M",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=aMO",
+		"FREQ=DAILY;WKST=XX",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+	}
+
+	for i, c := range cases {
+		_, err := Parse(c)
+		if err == nil {
+			t.Errorf("%d: %s: expected an error", i, c)
+		}
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic")
+		}
+	}()
+	MustParse("FREQ=NEVER")
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rrule implements RFC5545 recurrence rules (RRULE) and recurrence sets
+// (RRULE, RDATE and EXDATE). Rules are expanded lazily into dates (for all-day events)
+// or time spans (for timed events), so infinite rules can be iterated safely.
+//
+// The supported rule parts are FREQ (YEARLY, MONTHLY, WEEKLY or DAILY), INTERVAL,
+// COUNT, UNTIL, BYMONTH, BYWEEKNO, BYYEARDAY, BYMONTHDAY, BYDAY (including ordinals
+// such as "-1FR"), BYSETPOS and WKST. Sub-daily frequencies and the BYHOUR, BYMINUTE
+// and BYSECOND parts are not supported; the time of day of each occurrence is taken
+// from the start of the first occurrence.
+//
+// See https://www.rfc-editor.org/rfc/rfc5545#section-3.3.10
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Frequency identifies the type of recurrence rule.
+type Frequency int
+
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+)
+
+var frequencyNames = []string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY"}
+
+// String returns the RFC5545 name of the frequency.
+func (f Frequency) String() string {
+	if f < 0 || int(f) >= len(frequencyNames) {
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+	return frequencyNames[f]
+}
+
+// WeekdayNum is a day of the week with an optional ordinal, as used by BYDAY. For example,
+// "-1FR" is the last Friday and "2MO" is the second Monday. When N is zero, every such
+// weekday is specified.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// String returns the RFC5545 representation, e.g. "MO", "2MO" or "-1FR".
+func (wn WeekdayNum) String() string {
+	if wn.N == 0 {
+		return weekdayCodes[wn.Day]
+	}
+	return fmt.Sprintf("%d%s", wn.N, weekdayCodes[wn.Day])
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Rule is a recurrence rule. The slices are all optional; an empty slice means that the
+// corresponding rule part is absent.
+//
+// Use NewRule to obtain a rule with the RFC5545 defaults, particularly a week start
+// of Monday; note that the zero value of WeekStart is Sunday.
+type Rule struct {
+	Freq     Frequency
+	Interval int // zero is treated as one
+
+	// Count limits the number of occurrences; zero means no limit.
+	Count int
+
+	// Until is the inclusive upper bound of the occurrences; the zero value means no limit.
+	// UntilIsDate records whether it was (or will be) expressed as a date rather than a
+	// date-time. UntilIsFloating records that it is a wall-clock time without a zone: only
+	// its date and clock time are used, in the location of the first occurrence (see
+	// TimeSpans). Count and Until must not both be set.
+	Until           time.Time
+	UntilIsDate     bool
+	UntilIsFloating bool
+
+	ByMonth    []time.Month
+	ByWeekNo   []int
+	ByYearDay  []int
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	BySetPos   []int
+
+	WeekStart time.Weekday
+}
+
+// NewRule returns a rule with a given frequency, an interval of one and weeks
+// starting on Monday.
+func NewRule(freq Frequency) Rule {
+	return Rule{Freq: freq, Interval: 1, WeekStart: time.Monday}
+}
+
+// Validate checks the rule for inconsistencies that RFC5545 disallows.
+func (r Rule) Validate() error {
+	var errs []error
+
+	if r.Freq < Yearly || r.Freq > Daily {
+		errs = append(errs, fmt.Errorf("unsupported frequency %s", r.Freq))
+	}
+	if r.Interval < 0 {
+		errs = append(errs, fmt.Errorf("interval %d must not be negative", r.Interval))
+	}
+	if r.Count < 0 {
+		errs = append(errs, fmt.Errorf("count %d must not be negative", r.Count))
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		errs = append(errs, errors.New("count and until must not both be set"))
+	}
+
+	for _, m := range r.ByMonth {
+		if m < time.January || m > time.December {
+			errs = append(errs, fmt.Errorf("BYMONTH %d is out of range", m))
+		}
+	}
+	errs = checkRange(errs, "BYWEEKNO", r.ByWeekNo, 53)
+	errs = checkRange(errs, "BYYEARDAY", r.ByYearDay, 366)
+	errs = checkRange(errs, "BYMONTHDAY", r.ByMonthDay, 31)
+	errs = checkRange(errs, "BYSETPOS", r.BySetPos, 366)
+
+	if len(r.ByWeekNo) > 0 && r.Freq != Yearly {
+		errs = append(errs, errors.New("BYWEEKNO is only allowed with YEARLY"))
+	}
+	if len(r.ByYearDay) > 0 && (r.Freq == Monthly || r.Freq == Weekly) {
+		errs = append(errs, fmt.Errorf("BYYEARDAY is not allowed with %s", r.Freq))
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		errs = append(errs, errors.New("BYMONTHDAY is not allowed with WEEKLY"))
+	}
+
+	for _, wn := range r.ByDay {
+		if wn.Day < time.Sunday || wn.Day > time.Saturday {
+			errs = append(errs, fmt.Errorf("BYDAY weekday %d is out of range", wn.Day))
+		} else if wn.N != 0 {
+			if r.Freq != Monthly && r.Freq != Yearly {
+				errs = append(errs, fmt.Errorf("BYDAY %s ordinal is not allowed with %s", wn, r.Freq))
+			} else if r.Freq == Yearly && len(r.ByWeekNo) > 0 {
+				errs = append(errs, fmt.Errorf("BYDAY %s ordinal is not allowed with BYWEEKNO", wn))
+			} else if wn.N < -53 || wn.N > 53 {
+				errs = append(errs, fmt.Errorf("BYDAY %s ordinal is out of range", wn))
+			}
+		}
+	}
+
+	if len(r.BySetPos) > 0 && len(r.ByMonth)+len(r.ByWeekNo)+len(r.ByYearDay)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		errs = append(errs, errors.New("BYSETPOS requires another BYxxx rule part"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkRange(errs []error, name string, values []int, limit int) []error {
+	for _, v := range values {
+		if v == 0 || v < -limit || v > limit {
+			errs = append(errs, fmt.Errorf("%s %d is out of range", name, v))
+		}
+	}
+	return errs
+}
+
+func (r Rule) interval() int {
+	if r.Interval < 1 {
+		return 1
+	}
+	return r.Interval
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		rule Rule
+		ok   bool
+	}{
+		{rule: NewRule(Daily), ok: true},
+		{rule: Rule{Freq: Frequency(9)}},
+		{rule: Rule{Freq: Daily, Interval: -1}},
+		{rule: Rule{Freq: Daily, Count: -1}},
+		{rule: Rule{Freq: Daily, Count: 1, Until: time.Now()}},
+		{rule: Rule{Freq: Yearly, ByMonth: []time.Month{13}}},
+		{rule: Rule{Freq: Yearly, ByWeekNo: []int{54}}},
+		{rule: Rule{Freq: Monthly, ByWeekNo: []int{1}}},
+		{rule: Rule{Freq: Monthly, ByYearDay: []int{1}}},
+		{rule: Rule{Freq: Weekly, ByMonthDay: []int{1}}},
+		{rule: Rule{Freq: Monthly, ByMonthDay: []int{0}}},
+		{rule: Rule{Freq: Weekly, ByDay: []WeekdayNum{{N: 1, Day: time.Monday}}}},
+		{rule: Rule{Freq: Yearly, ByWeekNo: []int{1}, ByDay: []WeekdayNum{{N: 1, Day: time.Monday}}}},
+		{rule: Rule{Freq: Monthly, BySetPos: []int{1}}},
+		{rule: Rule{Freq: Monthly, ByDay: []WeekdayNum{{N: -1, Day: time.Friday}}, BySetPos: []int{-1}}, ok: true},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d %s", i, c.rule), func(t *testing.T) {
+			err := c.rule.Validate()
+			if (err == nil) != c.ok {
+				t.Errorf("%d: got %v", i, err)
+			}
+		})
+	}
+}
+
+func TestWeekdayNumString(t *testing.T) {
+	cases := []struct {
+		wn  WeekdayNum
+		exp string
+	}{
+		{WeekdayNum{Day: time.Sunday}, "SU"},
+		{WeekdayNum{N: 2, Day: time.Monday}, "2MO"},
+		{WeekdayNum{N: -1, Day: time.Friday}, "-1FR"},
+	}
+	for i, c := range cases {
+		if c.wn.String() != c.exp {
+			t.Errorf("%d: got %s, want %s", i, c.wn, c.exp)
+		}
+	}
+}
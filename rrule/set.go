@@ -0,0 +1,338 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Set is a recurrence set, which combines recurrence rules (RRULE) with explicit
+// recurrence dates (RDATE) and exception dates (EXDATE).
+//
+// As required by RFC5545, the start of the first occurrence (DTSTART) is always the
+// first element of the set unless it is excluded by EXDATE.
+type Set struct {
+	RRules  []Rule
+	RDates  []time.Time
+	ExDates []time.Time
+
+	// AllDay indicates that RDATE and EXDATE values are dates (VALUE=DATE); each is held
+	// as midnight UTC.
+	AllDay bool
+}
+
+// Dates expands the set into all-day occurrences, starting from dtstart, which is
+// itself the first occurrence. Duplicate dates are yielded only once.
+func (s Set) Dates(dtstart date.Date) *DateIterator {
+	iterators := make([]*DateIterator, 0, len(s.RRules)+1)
+	for _, r := range s.RRules {
+		iterators = append(iterators, r.Dates(dtstart))
+	}
+
+	rdates := []date.Date{dtstart}
+	for _, t := range s.RDates {
+		rdates = append(rdates, date.NewAt(t))
+	}
+	slices.Sort(rdates)
+	iterators = append(iterators, &DateIterator{next: sliceIterator(rdates).Next})
+
+	exdates := make(map[date.Date]struct{}, len(s.ExDates))
+	for _, t := range s.ExDates {
+		exdates[date.NewAt(t)] = struct{}{}
+	}
+
+	m := newMerger(iterators, func(a, b date.Date) bool { return a < b })
+
+	return &DateIterator{next: func() (date.Date, bool) {
+		for {
+			d, ok := m.next()
+			if !ok {
+				return 0, false
+			}
+			if _, excluded := exdates[d]; !excluded {
+				return d, true
+			}
+		}
+	}}
+}
+
+// TimeSpans expands the set into timed occurrences, starting from first, which is
+// itself the first occurrence. Each RDATE produces an occurrence with the same duration
+// as first. Occurrences that start at the same instant are yielded only once.
+//
+// When AllDay is true, each EXDATE excludes all occurrences that start on that date
+// in the location of first.
+func (s Set) TimeSpans(first timespan.TimeSpan) *TimeSpanIterator {
+	first = first.Normalise()
+	loc := first.Start().Location()
+
+	iterators := make([]*TimeSpanIterator, 0, len(s.RRules)+1)
+	for _, r := range s.RRules {
+		iterators = append(iterators, r.TimeSpans(first))
+	}
+
+	rdates := []timespan.TimeSpan{first}
+	for _, t := range s.RDates {
+		if s.AllDay {
+			t = date.NewAt(t).Time(clock.NewAt(first.Start()), loc)
+		}
+		rdates = append(rdates, timespan.TimeSpanOf(t, first.Duration()))
+	}
+	slices.SortFunc(rdates, func(a, b timespan.TimeSpan) int { return a.Start().Compare(b.Start()) })
+	iterators = append(iterators, &TimeSpanIterator{next: sliceIterator(rdates).Next})
+
+	m := newMerger(iterators, func(a, b timespan.TimeSpan) bool { return a.Start().Before(b.Start()) })
+
+	return &TimeSpanIterator{next: func() (timespan.TimeSpan, bool) {
+		for {
+			ts, ok := m.next()
+			if !ok {
+				return timespan.TimeSpan{}, false
+			}
+			if !s.isExcluded(ts.Start(), loc) {
+				return ts, true
+			}
+		}
+	}}
+}
+
+func (s Set) isExcluded(t time.Time, loc *time.Location) bool {
+	for _, ex := range s.ExDates {
+		if s.AllDay {
+			if date.NewAt(ex) == date.NewAt(t.In(loc)) {
+				return true
+			}
+		} else if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+//-------------------------------------------------------------------------------------------------
+
+type iterator[T any] interface {
+	Next() (T, bool)
+}
+
+type sliceIter[T any] struct {
+	list []T
+}
+
+func (it *sliceIter[T]) Next() (T, bool) {
+	var zero T
+	if len(it.list) == 0 {
+		return zero, false
+	}
+	v := it.list[0]
+	it.list = it.list[1:]
+	return v, true
+}
+
+func sliceIterator[T any](list []T) *sliceIter[T] {
+	return &sliceIter[T]{list: list}
+}
+
+// merger lazily merges several ascending sequences into one, dropping duplicates.
+type merger[T any] struct {
+	its   []iterator[T]
+	heads []T
+	valid []bool
+	less  func(a, b T) bool
+	last  *T
+}
+
+func newMerger[T any, I iterator[T]](its []I, less func(a, b T) bool) *merger[T] {
+	m := &merger[T]{less: less}
+	for _, it := range its {
+		m.its = append(m.its, it)
+	}
+	m.heads = make([]T, len(its))
+	m.valid = make([]bool, len(its))
+	for i, it := range m.its {
+		m.heads[i], m.valid[i] = it.Next()
+	}
+	return m
+}
+
+func (m *merger[T]) next() (T, bool) {
+	for {
+		best := -1
+		for i := range m.its {
+			if m.valid[i] && (best < 0 || m.less(m.heads[i], m.heads[best])) {
+				best = i
+			}
+		}
+
+		if best < 0 {
+			var zero T
+			return zero, false
+		}
+
+		v := m.heads[best]
+		m.heads[best], m.valid[best] = m.its[best].Next()
+
+		if m.last != nil && !m.less(*m.last, v) {
+			continue // duplicate
+		}
+		m.last = &v
+		return v, true
+	}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// MustParseSet is as per ParseSet except that it panics if the text cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseSet(text string, loc *time.Location) Set {
+	s, err := ParseSet(text, loc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ParseSet parses RRULE, RDATE and EXDATE content lines, one per line, for example
+//
+//	RRULE:FREQ=WEEKLY;BYDAY=MO,WE
+//	RDATE;VALUE=DATE:20240103,20240105
+//	EXDATE;TZID=Europe/London:20240108T090000
+//
+// Blank lines are ignored. Values without a zone, including RRULE UNTIL values, are in
+// the TZID location if present, or otherwise in loc. DATE values (VALUE=DATE, or eight
+// digits) cause AllDay to be set. Other properties, such as DTSTART, are rejected.
+func ParseSet(text string, loc *time.Location) (Set, error) {
+	var s Set
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, params, value, err := splitContentLine(line)
+		if err != nil {
+			return Set{}, fmt.Errorf("rrule.ParseSet: %w", err)
+		}
+
+		switch name {
+		case "RRULE":
+			r, err := parse(value, loc)
+			if err != nil {
+				return Set{}, err
+			}
+			s.RRules = append(s.RRules, r)
+
+		case "RDATE", "EXDATE":
+			times, allDay, err := parseDateList(params, value, loc)
+			if err != nil {
+				return Set{}, fmt.Errorf("rrule.ParseSet: cannot parse %q: %w", line, err)
+			}
+			s.AllDay = s.AllDay || allDay
+			if name == "RDATE" {
+				s.RDates = append(s.RDates, times...)
+			} else {
+				s.ExDates = append(s.ExDates, times...)
+			}
+
+		default:
+			return Set{}, fmt.Errorf("rrule.ParseSet: unsupported property %s", name)
+		}
+	}
+	return s, nil
+}
+
+// splitContentLine splits "NAME;PARAM=X;PARAM=Y:VALUE" into its parts. The parameter
+// names are upper-cased.
+func splitContentLine(line string) (string, map[string]string, string, error) {
+	colon := strings.IndexByte(line, ':')
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("missing ':' in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, found := strings.Cut(p, "=")
+		if !found {
+			return "", nil, "", fmt.Errorf("invalid parameter %q in %q", p, line)
+		}
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+func parseDateList(params map[string]string, value string, loc *time.Location) ([]time.Time, bool, error) {
+	switch params["VALUE"] {
+	case "", "DATE", "DATE-TIME":
+	default:
+		return nil, false, fmt.Errorf("VALUE=%s is not supported", params["VALUE"])
+	}
+
+	if tzid, exists := params["TZID"]; exists {
+		var err error
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	allDay := params["VALUE"] == "DATE"
+	var times []time.Time
+	for _, v := range strings.Split(value, ",") {
+		t, isDate, err := parseDateOrDateTime(v, loc)
+		if err != nil {
+			return nil, false, err
+		}
+		allDay = allDay || isDate
+		times = append(times, t)
+	}
+	return times, allDay, nil
+}
+
+// String formats the set as RFC5545 content lines separated by "\n".
+// Date-times in UTC use the "Z" suffix; date-times in any other location except
+// time.Local have a TZID parameter.
+func (s Set) String() string {
+	var lines []string
+	for _, r := range s.RRules {
+		lines = append(lines, "RRULE:"+r.String())
+	}
+	lines = append(lines, s.formatDateList("RDATE", s.RDates)...)
+	lines = append(lines, s.formatDateList("EXDATE", s.ExDates)...)
+	return strings.Join(lines, "\n")
+}
+
+// formatDateList groups consecutive values with the same location into one line.
+func (s Set) formatDateList(name string, times []time.Time) []string {
+	var lines []string
+	for i := 0; i < len(times); {
+		loc := times[i].Location()
+		j := i
+		var values []string
+		for j < len(times) && (s.AllDay || times[j].Location() == loc) {
+			values = append(values, formatDateOrDateTime(times[j], s.AllDay))
+			j++
+		}
+
+		prefix := name
+		if s.AllDay {
+			prefix += ";VALUE=DATE"
+		} else if loc != time.UTC && loc != time.Local {
+			prefix += ";TZID=" + loc.String()
+		}
+
+		lines = append(lines, prefix+":"+strings.Join(values, ","))
+		i = j
+	}
+	return lines
+}
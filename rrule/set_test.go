@@ -0,0 +1,131 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+func TestSetDates(t *testing.T) {
+	s := MustParseSet(`
+RRULE:FREQ=WEEKLY;COUNT=4;BYDAY=MO
+RRULE:FREQ=MONTHLY;COUNT=2;BYMONTHDAY=1
+RDATE;VALUE=DATE:20240103,20240108
+EXDATE;VALUE=DATE:20240115
+`, time.UTC)
+
+	if !s.AllDay {
+		t.Errorf("expected all-day")
+	}
+
+	got := join(s.Dates(date.New(2024, time.January, 1)).Take(20))
+	exp := "2024-01-01 2024-01-03 2024-01-08 2024-01-22 2024-02-01"
+	if got != exp {
+		t.Errorf("got %s, want %s", got, exp)
+	}
+}
+
+func TestSetDatesUnsyncedStart(t *testing.T) {
+	// DTSTART is a Tuesday, which does not match the rule but is included
+	s := Set{RRules: []Rule{MustParse("FREQ=WEEKLY;COUNT=2;BYDAY=MO")}}
+	got := join(s.Dates(date.New(2024, time.January, 2)).Take(20))
+	exp := "2024-01-02 2024-01-08 2024-01-15"
+	if got != exp {
+		t.Errorf("got %s, want %s", got, exp)
+	}
+}
+
+func TestSetTimeSpans(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	first := timespan.TimeSpanOf(time.Date(2024, time.January, 1, 9, 0, 0, 0, london), 30*time.Minute)
+
+	s := MustParseSet(`
+RRULE:FREQ=DAILY;COUNT=5
+RDATE;TZID=Europe/London:20240110T090000
+EXDATE:20240102T090000Z
+EXDATE;TZID=Europe/London:20240104T090000
+`, time.UTC)
+
+	spans := s.TimeSpans(first).Take(20)
+	exp := []string{
+		"20240101T090000/PT30M",
+		"20240103T090000/PT30M",
+		"20240105T090000/PT30M",
+		"20240110T090000/PT30M",
+	}
+	if len(spans) != len(exp) {
+		t.Fatalf("got %v", spans)
+	}
+	for i, ts := range spans {
+		if ts.In(london).Format("", "/", true) != exp[i] {
+			t.Errorf("%d: got %s, want %s", i, ts.In(london).Format("", "/", true), exp[i])
+		}
+	}
+}
+
+func TestSetTimeSpansAllDayExceptions(t *testing.T) {
+	first := timespan.TimeSpanOf(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC), time.Hour)
+	s := MustParseSet("RRULE:FREQ=DAILY;COUNT=3\nRDATE;VALUE=DATE:20240105\nEXDATE;VALUE=DATE:20240102", time.UTC)
+
+	spans := s.TimeSpans(first).Take(20)
+	if len(spans) != 3 {
+		t.Fatalf("got %v", spans)
+	}
+	if !spans[2].Start().Equal(time.Date(2024, time.January, 5, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v", spans[2])
+	}
+}
+
+func TestSetString(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	s := Set{
+		RRules: []Rule{MustParse("FREQ=DAILY;COUNT=5")},
+		RDates: []time.Time{
+			time.Date(2024, 1, 10, 9, 0, 0, 0, london),
+			time.Date(2024, 1, 11, 9, 0, 0, 0, london),
+			time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC),
+		},
+		ExDates: []time.Time{time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+	}
+
+	exp := "RRULE:FREQ=DAILY;COUNT=5\n" +
+		"RDATE;TZID=Europe/London:20240110T090000,20240111T090000\n" +
+		"RDATE:20240112T090000Z\n" +
+		"EXDATE:20240102T090000Z"
+	if s.String() != exp {
+		t.Errorf("got\n%s\nwant\n%s", s, exp)
+	}
+
+	s2 := MustParseSet(s.String(), time.UTC)
+	if s2.String() != exp {
+		t.Errorf("got\n%s\nwant\n%s", s2, exp)
+	}
+
+	allDay := MustParseSet("RDATE;VALUE=DATE:20240110,20240111", time.UTC)
+	if allDay.String() != "RDATE;VALUE=DATE:20240110,20240111" {
+		t.Errorf("got %s", allDay)
+	}
+}
+
+func TestParseSetErrors(t *testing.T) {
+	cases := []string{
+		"DTSTART:20240101",
+		"RRULE:FREQ=NEVER",
+		"RDATE",
+		"RDATE;VALUE:20240101",
+		"RDATE;VALUE=PERIOD:20240101T000000Z/PT1H",
+		"RDATE;TZID=Nowhere/Special:20240101T090000",
+		"EXDATE:2024",
+	}
+	for i, c := range cases {
+		if _, err := ParseSet(c, time.UTC); err == nil {
+			t.Errorf("%d: %s: expected an error", i, c)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package series
+
+import (
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Aggregate is the result of combining the values in one bucket of a series.
+type Aggregate[U any] struct {
+	timespan.Bucket
+	Value U
+}
+
+// Resample combines the values of a series into buckets, such as weeks or months, using
+// an aggregation function. Typically, the buckets are obtained by splitting the range of
+// the series, e.g.
+//
+//	monthly := series.Resample(s, s.Range().SplitBy(timespan.Month), series.Sum)
+//
+// The parts of the buckets that lie outside the series are ignored; buckets that do not
+// overlap the series at all are omitted. The aggregation function is never given an empty
+// slice, so functions such as slices.Max can also be used.
+func Resample[T, U any](s Series[T], buckets []timespan.Bucket, agg func([]T) U) []Aggregate[U] {
+	result := make([]Aggregate[U], 0, len(buckets))
+	for _, b := range buckets {
+		part := s.Slice(b.Range)
+		if !part.IsEmpty() {
+			result = append(result, Aggregate[U]{Bucket: b, Value: agg(part.values)})
+		}
+	}
+	return result
+}
+
+// Number is the constraint for the values that can be summed and averaged.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Sum adds the values together.
+func Sum[T Number](values []T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Mean gets the arithmetic mean of the values. It returns zero if there are none.
+func Mean[T Number](values []T) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range values {
+		total += float64(v)
+	}
+	return total / float64(len(values))
+}
+
+// First gets the first value, such as the opening price in each week. It returns
+// the zero value if there are none.
+func First[T any](values []T) T {
+	var v T
+	if len(values) > 0 {
+		v = values[0]
+	}
+	return v
+}
+
+// Last gets the last value, such as the closing price in each week. It returns
+// the zero value if there are none.
+func Last[T any](values []T) T {
+	var v T
+	if len(values) > 0 {
+		v = values[len(values)-1]
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package series
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+func aggregatesString[U any](aggs []Aggregate[U]) string {
+	s := make([]string, len(aggs))
+	for i, a := range aggs {
+		s[i] = fmt.Sprintf("%s=%v", a.Range.Start(), a.Value)
+		if a.Partial {
+			s[i] += "*"
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func TestResample(t *testing.T) {
+	// daily prices from Wednesday 28th February to Tuesday 12th March 2024
+	start := date.MustParseISO("2024-02-28")
+	s := Make[int](timespan.BetweenDates(start, start+14))
+	for i := range s.Values() {
+		s.Values()[i] = i + 1
+	}
+
+	cases := []struct {
+		buckets []timespan.Bucket
+		agg     func([]int) int
+		want    string
+	}{
+		{
+			buckets: s.Range().SplitBy(timespan.Week),
+			agg:     Sum[int],
+			want:    "2024-02-28=15* 2024-03-04=63 2024-03-11=27*",
+		},
+		{
+			buckets: s.Range().SplitByWeeks(time.Sunday),
+			agg:     First[int],
+			want:    "2024-02-28=1* 2024-03-03=5 2024-03-10=12*",
+		},
+		{
+			buckets: s.Range().SplitBy(timespan.Month),
+			agg:     Last[int],
+			want:    "2024-02-28=2* 2024-03-01=14*",
+		},
+		{
+			buckets: s.Range().SplitBy(timespan.Month),
+			agg:     slices.Max[[]int],
+			want:    "2024-02-28=2* 2024-03-01=14*",
+		},
+		{
+			// buckets partly or wholly outside the series
+			buckets: timespan.BetweenDates(start-40, start+100).SplitBy(timespan.Month),
+			agg:     Sum[int],
+			want:    "2024-02-01=3 2024-03-01=102",
+		},
+	}
+
+	for i, c := range cases {
+		got := aggregatesString(Resample(s, c.buckets, c.agg))
+		if got != c.want {
+			t.Errorf("%d: got %s, want %s", i, got, c.want)
+		}
+	}
+
+	// the aggregation function's type argument is inferred
+	sums := Resample(s, s.Range().SplitBy(timespan.Month), Sum)
+	if aggregatesString(sums) != "2024-02-28=3* 2024-03-01=102*" {
+		t.Errorf("got %s", aggregatesString(sums))
+	}
+
+	means := Resample(s, s.Range().SplitBy(timespan.Month), Mean[int])
+	if aggregatesString(means) != "2024-02-28=1.5* 2024-03-01=8.5*" {
+		t.Errorf("got %s", aggregatesString(means))
+	}
+}
+
+func TestAggregationFunctions(t *testing.T) {
+	values := []float64{3, 1, 4, 1, 5}
+	if Sum(values) != 14 || Mean(values) != 2.8 || First(values) != 3 || Last(values) != 5 {
+		t.Errorf("got %v %v %v %v", Sum(values), Mean(values), First(values), Last(values))
+	}
+	if Sum[int](nil) != 0 || Mean[int](nil) != 0 || First[string](nil) != "" || Last[string](nil) != "" {
+		t.Errorf("unexpected result for no values")
+	}
+}
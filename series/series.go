@@ -0,0 +1,174 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package series provides a dense container holding one value per day over a contiguous
+// range of dates, such as a price or a capacity on each day over several years.
+package series
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+// Series holds one value for each date in a contiguous range of dates. Indexing by date
+// takes O(1) time.
+//
+// Like a slice, a Series refers to an underlying array of values: copies of a series and
+// series obtained using Slice share their values with the original, so Set alters them all.
+// The zero value is an empty series.
+type Series[T any] struct {
+	start  date.Date
+	values []T
+}
+
+// New constructs a series holding the given values for consecutive dates from a
+// start date onwards. The series refers to the values slice; it is not copied.
+func New[T any](start date.Date, values ...T) Series[T] {
+	return Series[T]{start: start, values: values}
+}
+
+// Make constructs a series holding the zero value for every date in a date range.
+func Make[T any](dateRange timespan.DateRange) Series[T] {
+	return Series[T]{start: dateRange.Start(), values: make([]T, dateRange.Days())}
+}
+
+// Range returns the range of dates in the series.
+func (s Series[T]) Range() timespan.DateRange {
+	return timespan.DayRange(s.start, timespan.PeriodOfDays(len(s.values)))
+}
+
+// Len returns the number of dates in the series.
+func (s Series[T]) Len() int {
+	return len(s.values)
+}
+
+// IsEmpty returns true if the series holds no values.
+func (s Series[T]) IsEmpty() bool {
+	return len(s.values) == 0
+}
+
+// At returns the value for a date. The flag is false if the date is outside the series,
+// in which case the zero value is returned.
+func (s Series[T]) At(d date.Date) (T, bool) {
+	i := d - s.start
+	if i < 0 || int64(i) >= int64(len(s.values)) {
+		var zero T
+		return zero, false
+	}
+	return s.values[i], true
+}
+
+// Set alters the value for a date. It returns false, and does nothing, if the date is
+// outside the series.
+func (s Series[T]) Set(d date.Date, v T) bool {
+	i := d - s.start
+	if i < 0 || int64(i) >= int64(len(s.values)) {
+		return false
+	}
+	s.values[i] = v
+	return true
+}
+
+// Values returns the values in date order. The slice refers to the series' own values.
+func (s Series[T]) Values() []T {
+	return s.values
+}
+
+// All returns an iterator over the dates and their values, in date order.
+func (s Series[T]) All() iter.Seq2[date.Date, T] {
+	return func(yield func(date.Date, T) bool) {
+		for i, v := range s.values {
+			if !yield(s.start+date.Date(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the part of the series that lies within a date range. The result shares
+// its values with the original. If the date range does not overlap the series, the result
+// is empty.
+func (s Series[T]) Slice(dateRange timespan.DateRange) Series[T] {
+	r := s.Range().Intersect(dateRange)
+	i := r.Start() - s.start
+	if r.IsEmpty() || i < 0 {
+		return Series[T]{start: r.Start()}
+	}
+	return Series[T]{start: r.Start(), values: s.values[i : int(i)+int(r.Days())]}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// FillPolicy decides how FromMap treats dates that have no value.
+type FillPolicy int
+
+const (
+	// FillZero uses the zero value for missing dates.
+	FillZero FillPolicy = iota
+
+	// FillForward uses the value from the most recent earlier date that has one. This may
+	// be a date before the range. If there is none, the zero value is used.
+	FillForward
+
+	// FillError treats missing dates as an error.
+	FillError
+)
+
+var fillPolicyNames = [...]string{"zero", "forward", "error"}
+
+func (fill FillPolicy) String() string {
+	if fill >= 0 && int(fill) < len(fillPolicyNames) {
+		return fillPolicyNames[fill]
+	}
+	return fmt.Sprintf("FillPolicy(%d)", int(fill))
+}
+
+// FromMap constructs a series for a date range from a sparse set of values, using a fill
+// policy for the dates in the range that have no value. Values for dates outside the
+// range are ignored, except that FillForward may carry one in from before the start.
+func FromMap[T any](values map[date.Date]T, dateRange timespan.DateRange, fill FillPolicy) (Series[T], error) {
+	s := Make[T](dateRange)
+
+	var previous T
+	if fill == FillForward {
+		latest, found := date.Date(0), false
+		for d := range values {
+			if d < dateRange.Start() && (!found || d > latest) {
+				latest, found = d, true
+			}
+		}
+		if found {
+			previous = values[latest]
+		}
+	}
+
+	for i := range s.values {
+		d := s.start + date.Date(i)
+		v, ok := values[d]
+		switch {
+		case ok:
+			previous = v
+		case fill == FillForward:
+			v = previous
+		case fill == FillError:
+			return Series[T]{}, fmt.Errorf("series.FromMap: there is no value for %s", d)
+		}
+		s.values[i] = v
+	}
+
+	return s, nil
+}
+
+// Missing finds the dates within a date range that have no value, as runs of
+// consecutive dates. This is useful for checking input data before using FromMap.
+func Missing[T any](values map[date.Date]T, dateRange timespan.DateRange) timespan.DateRangeSet {
+	present := make([]timespan.DateRange, 0, len(values))
+	for d := range values {
+		present = append(present, timespan.OneDayRange(d))
+	}
+	return timespan.NewDateRangeSet(present...).Complement(dateRange)
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package series
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/timespan"
+)
+
+var (
+	d0301 = date.MustParseISO("2024-03-01")
+	d0305 = date.MustParseISO("2024-03-05")
+	d0310 = date.MustParseISO("2024-03-10")
+)
+
+func TestSeriesIndexing(t *testing.T) {
+	s := New(d0301, 10, 11, 12, 13, 14)
+
+	if s.Len() != 5 || s.Range() != timespan.BetweenDates(d0301, d0301+5) {
+		t.Errorf("got %d %v", s.Len(), s.Range())
+	}
+
+	cases := []struct {
+		d    date.Date
+		v    int
+		want bool
+	}{
+		{d: d0301 - 1},
+		{d: d0301, v: 10, want: true},
+		{d: d0305, v: 14, want: true},
+		{d: d0305 + 1},
+	}
+
+	for i, c := range cases {
+		v, ok := s.At(c.d)
+		if v != c.v || ok != c.want {
+			t.Errorf("%d: %s got %d %v, want %d %v", i, c.d, v, ok, c.v, c.want)
+		}
+	}
+
+	if !s.Set(d0305, 99) || s.Values()[4] != 99 {
+		t.Errorf("Set in range failed: %v", s.Values())
+	}
+	if s.Set(d0310, 99) {
+		t.Errorf("Set out of range succeeded")
+	}
+
+	var dates []string
+	for d, v := range s.All() {
+		dates = append(dates, fmt.Sprintf("%s=%d", d, v))
+		if v == 12 {
+			break
+		}
+	}
+	if fmt.Sprint(dates) != "[2024-03-01=10 2024-03-02=11 2024-03-03=12]" {
+		t.Errorf("got %v", dates)
+	}
+
+	var zero Series[string]
+	if !zero.IsEmpty() || !zero.Range().IsEmpty() {
+		t.Errorf("zero series is not empty")
+	}
+	if _, ok := zero.At(0); ok {
+		t.Errorf("zero series has a value")
+	}
+}
+
+func TestSeriesSlice(t *testing.T) {
+	s := Make[float64](timespan.BetweenDates(d0301, d0310))
+	for i := range s.Values() {
+		s.Values()[i] = float64(i)
+	}
+
+	cases := []struct {
+		r    timespan.DateRange
+		want string
+	}{
+		{r: timespan.BetweenDates(d0305, d0305+2), want: "2024-03-05 [4 5]"},
+		{r: timespan.BetweenDates(d0301-5, d0301+2), want: "2024-03-01 [0 1]"},
+		{r: timespan.BetweenDates(d0310-1, d0310+5), want: "2024-03-09 [8]"},
+		{r: timespan.BetweenDates(d0310, d0310+5), want: "2024-03-10 []"},
+		{r: timespan.EmptyRange(d0305), want: "2024-03-05 []"},
+	}
+
+	for i, c := range cases {
+		part := s.Slice(c.r)
+		got := fmt.Sprintf("%s %v", part.Range().Start(), part.Values())
+		if got != c.want {
+			t.Errorf("%d: %v got %s, want %s", i, c.r, got, c.want)
+		}
+	}
+
+	part := s.Slice(timespan.BetweenDates(d0305, d0310))
+	part.Set(d0305, 100)
+	if v, _ := s.At(d0305); v != 100 {
+		t.Errorf("slice does not share values with the original")
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	values := map[date.Date]int{
+		d0301 - 3: 7,
+		d0301 + 1: 1,
+		d0301 + 2: 2,
+		d0305:     5,
+		d0310:     10,
+	}
+	r := timespan.BetweenDates(d0301, d0301+7)
+
+	cases := []struct {
+		fill FillPolicy
+		want string
+		err  string
+	}{
+		{fill: FillZero, want: "[0 1 2 0 5 0 0]"},
+		{fill: FillForward, want: "[7 1 2 2 5 5 5]"},
+		{fill: FillError, err: "series.FromMap: there is no value for 2024-03-01"},
+	}
+
+	for i, c := range cases {
+		s, err := FromMap(values, r, c.fill)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%d: %s got %v, want %s", i, c.fill, err, c.err)
+			}
+			continue
+		}
+		if err != nil || fmt.Sprint(s.Values()) != c.want || s.Range() != r {
+			t.Errorf("%d: %s got %v %v, want %s", i, c.fill, s.Values(), err, c.want)
+		}
+	}
+
+	s, err := FromMap(values, timespan.BetweenDates(d0301+1, d0301+3), FillError)
+	if err != nil || fmt.Sprint(s.Values()) != "[1 2]" {
+		t.Errorf("got %v %v", s.Values(), err)
+	}
+
+	if FillForward.String() != "forward" || FillPolicy(7).String() != "FillPolicy(7)" {
+		t.Errorf("got %s %s", FillForward, FillPolicy(7))
+	}
+}
+
+func TestMissing(t *testing.T) {
+	values := map[date.Date]bool{
+		d0301 - 3: true,
+		d0301 + 1: true,
+		d0301 + 2: true,
+		d0305:     true,
+	}
+
+	got := Missing(values, timespan.BetweenDates(d0301, d0310))
+	if got.String() != "2024-03-01,2024-03-04,2024-03-06/2024-03-09" {
+		t.Errorf("got %s", got)
+	}
+
+	if !Missing(values, timespan.BetweenDates(d0301+1, d0301+3)).IsEmpty() {
+		t.Errorf("expected nothing to be missing")
+	}
+}
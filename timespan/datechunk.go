@@ -0,0 +1,221 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"github.com/rickb777/date/v2"
+)
+
+const (
+	chunkShift = 10 // each chunk holds 1024 days, i.e. about 2.8 years
+	chunkDays  = 1 << chunkShift
+	chunkWords = chunkDays / 64
+
+	// the sizes in bytes of each container
+	bitmapBytes = chunkWords * 8
+	arrayBytes  = 2 // per date
+	runBytes    = 4 // per run
+
+	// arrayMax is the most dates held in an array, which is then no larger than a bitmap
+	arrayMax = bitmapBytes / arrayBytes
+)
+
+// chunkBitmap has one bit for each day in a chunk.
+type chunkBitmap [chunkWords]uint64
+
+// setRange sets the bits from lo (inclusive) to hi (exclusive).
+func (b *chunkBitmap) setRange(lo, hi int) {
+	for lo < hi {
+		n := min(hi-lo, 64-lo%64)
+		b[lo/64] |= (1<<n - 1) << (lo % 64)
+		lo += n
+	}
+}
+
+// countRange counts the bits from lo (inclusive) to hi (exclusive).
+func (b *chunkBitmap) countRange(lo, hi int) int {
+	total := 0
+	for lo < hi {
+		n := min(hi-lo, 64-lo%64)
+		total += bits.OnesCount64(b[lo/64] >> (lo % 64) & (1<<n - 1))
+		lo += n
+	}
+	return total
+}
+
+// offsets iterates over the set bits in ascending order.
+func (b *chunkBitmap) offsets() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b {
+			for w != 0 {
+				if !yield(i*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// dateRun is a run of consecutive days in a chunk, from first to last inclusive.
+type dateRun struct {
+	first, last uint16
+}
+
+// dateChunk holds the dates in one aligned block of chunkDays days, using whichever of
+// three containers is smallest, as a roaring bitmap does: an array of the offsets of the
+// dates when there are at most arrayMax of them, a list of runs when the dates are mostly
+// consecutive, or otherwise a bitmap.
+//
+// Exactly one container is present; it depends only on the dates, so equal chunks have
+// equal containers. Containers are never altered once built, so chunks can be shared
+// between sets.
+type dateChunk struct {
+	key    int64    // the date of the first day, shifted right by chunkShift
+	array  []uint16 // ascending offsets
+	runs   []dateRun
+	bitmap *chunkBitmap
+}
+
+func splitDate(d date.Date) (key int64, offset int) {
+	return int64(d) >> chunkShift, int(int64(d) & (chunkDays - 1))
+}
+
+// newChunk builds the chunk for a bitmap, with its smallest container. The chunk is
+// empty if no bits are set.
+func newChunk(key int64, b *chunkBitmap) dateChunk {
+	count, runs := 0, 0
+	var carry uint64 // the last bit of the previous word
+	for _, w := range b {
+		count += bits.OnesCount64(w)
+		runs += bits.OnesCount64(w &^ (w<<1 | carry)) // each run starts with a bit after a gap
+		carry = w >> 63
+	}
+
+	c := dateChunk{key: key}
+	switch {
+	case count == 0:
+	case runs*runBytes < min(count*arrayBytes, bitmapBytes):
+		c.runs = make([]dateRun, 0, runs)
+		for offset := range b.offsets() {
+			if n := len(c.runs); n > 0 && int(c.runs[n-1].last)+1 == offset {
+				c.runs[n-1].last++
+			} else {
+				c.runs = append(c.runs, dateRun{first: uint16(offset), last: uint16(offset)})
+			}
+		}
+	case count <= arrayMax:
+		c.array = make([]uint16, 0, count)
+		for offset := range b.offsets() {
+			c.array = append(c.array, uint16(offset))
+		}
+	default:
+		c.bitmap = new(chunkBitmap)
+		*c.bitmap = *b
+	}
+	return c
+}
+
+func (c *dateChunk) isEmpty() bool {
+	return c.array == nil && c.runs == nil && c.bitmap == nil
+}
+
+// toBitmap converts the chunk to a bitmap, whatever its container.
+func (c *dateChunk) toBitmap() chunkBitmap {
+	var b chunkBitmap
+	switch {
+	case c.bitmap != nil:
+		b = *c.bitmap
+	case c.runs != nil:
+		for _, r := range c.runs {
+			b.setRange(int(r.first), int(r.last)+1)
+		}
+	default:
+		for _, offset := range c.array {
+			b[offset/64] |= 1 << (offset % 64)
+		}
+	}
+	return b
+}
+
+func (c *dateChunk) contains(offset int) bool {
+	switch {
+	case c.bitmap != nil:
+		return c.bitmap[offset/64]&(1<<(offset%64)) != 0
+	case c.runs != nil:
+		_, found := slices.BinarySearchFunc(c.runs, uint16(offset), compareRun)
+		return found
+	}
+	_, found := slices.BinarySearch(c.array, uint16(offset))
+	return found
+}
+
+// compareRun compares a run with an offset, giving zero if the run contains it.
+func compareRun(r dateRun, offset uint16) int {
+	switch {
+	case r.last < offset:
+		return -1
+	case r.first > offset:
+		return 1
+	}
+	return 0
+}
+
+func (c *dateChunk) count() int {
+	return c.countBetween(0, chunkDays)
+}
+
+// countBetween counts the dates from offset lo (inclusive) to hi (exclusive).
+func (c *dateChunk) countBetween(lo, hi int) int {
+	switch {
+	case c.bitmap != nil:
+		return c.bitmap.countRange(lo, hi)
+	case c.runs != nil:
+		n := 0
+		for _, r := range c.runs {
+			n += max(0, min(hi, int(r.last)+1)-max(lo, int(r.first)))
+		}
+		return n
+	}
+	i, _ := slices.BinarySearch(c.array, uint16(lo))
+	j, _ := slices.BinarySearch(c.array, uint16(hi))
+	return j - i
+}
+
+// offsets iterates over the offsets of the dates in ascending order.
+func (c *dateChunk) offsets() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		switch {
+		case c.bitmap != nil:
+			c.bitmap.offsets()(yield)
+		case c.runs != nil:
+			for _, r := range c.runs {
+				for offset := int(r.first); offset <= int(r.last); offset++ {
+					if !yield(offset) {
+						return
+					}
+				}
+			}
+		default:
+			for _, offset := range c.array {
+				if !yield(int(offset)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *dateChunk) equal(other *dateChunk) bool {
+	return c.key == other.key &&
+		slices.Equal(c.array, other.array) &&
+		slices.Equal(c.runs, other.runs) &&
+		(c.bitmap == nil) == (other.bitmap == nil) &&
+		(c.bitmap == nil || *c.bitmap == *other.bitmap)
+}
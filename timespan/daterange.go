@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
 	"github.com/rickb777/period"
 )
 
@@ -259,6 +260,68 @@ func (dateRange DateRange) Merge(otherRange DateRange) DateRange {
 	return BetweenDates(minStart, maxEnd)
 }
 
+// Overlaps tests whether the two date ranges have at least one date in common.
+// Ranges that merely abut do not overlap. Empty ranges never overlap anything.
+func (dateRange DateRange) Overlaps(otherRange DateRange) bool {
+	if dateRange.days == 0 || otherRange.days == 0 {
+		return false
+	}
+	return dateRange.start < otherRange.End() && otherRange.start < dateRange.End()
+}
+
+// Abuts tests whether one date range ends exactly where the other begins, so that they
+// are adjacent without any gap or overlap. Empty ranges never abut anything.
+func (dateRange DateRange) Abuts(otherRange DateRange) bool {
+	if dateRange.days == 0 || otherRange.days == 0 {
+		return false
+	}
+	return dateRange.End() == otherRange.start || otherRange.End() == dateRange.start
+}
+
+// Intersect returns the dates that are in both date ranges. If the ranges do not
+// overlap, the result is an empty range starting at the later of the two start dates.
+func (dateRange DateRange) Intersect(otherRange DateRange) DateRange {
+	maxStart := max(dateRange.start, otherRange.start)
+	minEnd := min(dateRange.End(), otherRange.End())
+	if minEnd <= maxStart || dateRange.days == 0 || otherRange.days == 0 {
+		return EmptyRange(maxStart)
+	}
+	return BetweenDates(maxStart, minEnd)
+}
+
+// Subtract returns the dates in this range that are not in the other range. The result
+// contains zero, one or two non-empty ranges, in ascending order: there are two when
+// the other range lies strictly inside this one.
+func (dateRange DateRange) Subtract(otherRange DateRange) []DateRange {
+	if dateRange.days == 0 {
+		return nil
+	}
+	if !dateRange.Overlaps(otherRange) {
+		return []DateRange{dateRange}
+	}
+
+	var result []DateRange
+	if dateRange.start < otherRange.start {
+		result = append(result, BetweenDates(dateRange.start, otherRange.start))
+	}
+	if otherRange.End() < dateRange.End() {
+		result = append(result, BetweenDates(otherRange.End(), dateRange.End()))
+	}
+	return result
+}
+
+// Gap returns the dates that lie between the two date ranges. If the ranges overlap or
+// abut, there is no gap and the result is empty; its start date is then the later of the
+// two start dates.
+func (dateRange DateRange) Gap(otherRange DateRange) DateRange {
+	minEnd := min(dateRange.End(), otherRange.End())
+	maxStart := max(dateRange.start, otherRange.start)
+	if maxStart <= minEnd {
+		return EmptyRange(maxStart)
+	}
+	return BetweenDates(minEnd, maxStart)
+}
+
 // Duration computes the duration (in nanoseconds) from midnight at the start of the date
 // range up to and including the very last nanosecond before midnight on the end day.
 // The calculation is for UTC, which does not have daylight saving and every day has 24 hours.
@@ -300,3 +363,11 @@ func (dateRange DateRange) TimeSpanIn(loc *time.Location) TimeSpan {
 	d := dateRange.DurationIn(loc)
 	return TimeSpan{s, d}
 }
+
+// TimeSpanWithCutoff obtains the time span covered by the date range when the dates are
+// business dates that roll over at a cutoff time rather than at midnight; see
+// date.NewAtCutoff. So with a cutoff of 17:00, the span of a single business date starts
+// at 17:00 on the previous date and ends at 17:00 on the date itself.
+func (dateRange DateRange) TimeSpanWithCutoff(loc *time.Location, cutoff clock.Clock) TimeSpan {
+	return BetweenTimes((dateRange.start-1).Time(cutoff, loc), (dateRange.End()-1).Time(cutoff, loc))
+}
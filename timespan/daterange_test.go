@@ -11,6 +11,7 @@ import (
 	"time"
 
 	. "github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
 	"github.com/rickb777/period"
 )
 
@@ -375,3 +376,128 @@ func isEq(t *testing.T, i int, a, b interface{}, msg ...interface{}) {
 		t.Errorf("%d: %+v is not equal to %+v%s", i, a, b, strings.Join(sa, ""))
 	}
 }
+
+func TestOverlapsAndAbuts(t *testing.T) {
+	b := BetweenDates(d0325, d0331)
+
+	cases := []struct {
+		a               DateRange
+		overlaps, abuts bool
+	}{
+		{a: BetweenDates(d0320, d0321)},
+		{a: BetweenDates(d0320, d0325), abuts: true},
+		{a: BetweenDates(d0320, d0326), overlaps: true},
+		{a: BetweenDates(d0326, d0327), overlaps: true},
+		{a: BetweenDates(d0330, d0401), overlaps: true},
+		{a: BetweenDates(d0331, d0401), abuts: true},
+		{a: BetweenDates(d0401, d0402)},
+		{a: EmptyRange(d0325)},
+		{a: EmptyRange(d0327)},
+		{a: EmptyRange(d0331)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Overlaps(b), c.overlaps, c.a)
+		isEq(t, i, b.Overlaps(c.a), c.overlaps, c.a)
+		isEq(t, i, c.a.Abuts(b), c.abuts, c.a)
+		isEq(t, i, b.Abuts(c.a), c.abuts, c.a)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	b := BetweenDates(d0325, d0331)
+
+	cases := []struct {
+		a, exp DateRange
+	}{
+		{a: BetweenDates(d0320, d0321), exp: EmptyRange(d0325)},
+		{a: BetweenDates(d0320, d0325), exp: EmptyRange(d0325)},
+		{a: BetweenDates(d0320, d0327), exp: BetweenDates(d0325, d0327)},
+		{a: BetweenDates(d0326, d0328), exp: BetweenDates(d0326, d0328)},
+		{a: BetweenDates(d0320, d0401), exp: b},
+		{a: BetweenDates(d0330, d0402), exp: BetweenDates(d0330, d0331)},
+		{a: BetweenDates(d0331, d0401), exp: EmptyRange(d0331)},
+		{a: EmptyRange(d0327), exp: EmptyRange(d0327)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Intersect(b), c.exp, c.a)
+		isEq(t, i, b.Intersect(c.a), c.exp, c.a)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	b := BetweenDates(d0325, d0331)
+
+	cases := []struct {
+		a   DateRange
+		exp []DateRange
+	}{
+		{a: BetweenDates(d0320, d0321), exp: []DateRange{b}},
+		{a: BetweenDates(d0320, d0325), exp: []DateRange{b}},
+		{a: BetweenDates(d0320, d0327), exp: []DateRange{BetweenDates(d0327, d0331)}},
+		{a: BetweenDates(d0326, d0328), exp: []DateRange{BetweenDates(d0325, d0326), BetweenDates(d0328, d0331)}},
+		{a: BetweenDates(d0325, d0327), exp: []DateRange{BetweenDates(d0327, d0331)}},
+		{a: BetweenDates(d0327, d0331), exp: []DateRange{BetweenDates(d0325, d0327)}},
+		{a: BetweenDates(d0330, d0402), exp: []DateRange{BetweenDates(d0325, d0330)}},
+		{a: BetweenDates(d0320, d0401), exp: nil},
+		{a: b, exp: nil},
+		{a: EmptyRange(d0327), exp: []DateRange{b}},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(b.Subtract(c.a)), fmt.Sprint(c.exp), c.a)
+	}
+
+	isEq(t, 0, len(EmptyRange(d0327).Subtract(b)), 0)
+}
+
+func TestGap(t *testing.T) {
+	b := BetweenDates(d0325, d0331)
+
+	cases := []struct {
+		a, exp DateRange
+	}{
+		{a: BetweenDates(d0320, d0321), exp: BetweenDates(d0321, d0325)},
+		{a: BetweenDates(d0320, d0325), exp: EmptyRange(d0325)},
+		{a: BetweenDates(d0320, d0327), exp: EmptyRange(d0325)},
+		{a: BetweenDates(d0331, d0401), exp: EmptyRange(d0331)},
+		{a: BetweenDates(d0402, d0404), exp: BetweenDates(d0331, d0402)},
+		{a: EmptyRange(d0401), exp: OneDayRange(d0331)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Gap(b), c.exp, c.a)
+		isEq(t, i, b.Gap(c.a), c.exp, c.a)
+	}
+}
+
+func TestTimeSpanWithCutoff(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	fivePM := clock.New(17, 0, 0, 0)
+
+	cases := []struct {
+		dr     DateRange
+		cutoff clock.Clock
+		exp    string
+	}{
+		// New York clocks go forward at 2am on 8th March 2015
+		{dr: OneDayRange(d0320), cutoff: fivePM, exp: "2015-03-19T17:00:00-04:00/24h0m0s"},
+		{dr: OneDayRange(New(2015, 3, 8)), cutoff: fivePM, exp: "2015-03-07T17:00:00-05:00/23h0m0s"},
+		{dr: DayRange(d0320, 3), cutoff: fivePM, exp: "2015-03-19T17:00:00-04:00/72h0m0s"},
+		{dr: OneDayRange(d0320), cutoff: clock.Day, exp: "2015-03-20T00:00:00-04:00/24h0m0s"},
+		{dr: OneDayRange(d0320), cutoff: clock.New(30, 0, 0, 0), exp: "2015-03-20T06:00:00-04:00/24h0m0s"},
+		{dr: EmptyRange(d0320), cutoff: fivePM, exp: "2015-03-19T17:00:00-04:00/0s"},
+	}
+
+	for i, c := range cases {
+		ts := c.dr.TimeSpanWithCutoff(newYork, c.cutoff)
+		isEq(t, i, ts.Start().Format(time.RFC3339)+"/"+ts.Duration().String(), c.exp)
+
+		if !c.dr.IsEmpty() {
+			isEq(t, i, NewAtCutoff(ts.Start(), newYork, c.cutoff), c.dr.Start())
+			isEq(t, i, NewAtCutoff(ts.End().Add(-1), newYork, c.cutoff), c.dr.Last())
+			isEq(t, i, NewAtCutoff(ts.End(), newYork, c.cutoff), c.dr.End())
+		}
+	}
+}
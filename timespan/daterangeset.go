@@ -0,0 +1,220 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rickb777/date/v2"
+)
+
+// DateRangeSet is a set of dates held as a sorted list of disjoint, non-empty date ranges.
+// Ranges that overlap or abut are coalesced, so every set has exactly one representation.
+// The zero value is an empty set.
+//
+// Like DateRange, DateRangeSet is a value type: the operations return new sets and never
+// alter their receiver.
+type DateRangeSet struct {
+	ranges []DateRange
+}
+
+// NewDateRangeSet constructs a set containing all the dates in the given ranges.
+// Empty ranges are ignored.
+func NewDateRangeSet(ranges ...DateRange) DateRangeSet {
+	return DateRangeSet{ranges: normaliseRanges(slices.Clone(ranges))}
+}
+
+// normaliseRanges sorts the ranges, drops empty ones and coalesces any that overlap or abut.
+// The slice is altered in place.
+func normaliseRanges(ranges []DateRange) []DateRange {
+	ranges = slices.DeleteFunc(ranges, DateRange.IsEmpty)
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(ranges, func(a, b DateRange) int {
+		return cmp.Compare(a.start, b.start)
+	})
+
+	result := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &result[len(result)-1]
+		if r.start <= last.End() {
+			*last = BetweenDates(last.start, max(last.End(), r.End()))
+		} else {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Ranges returns the disjoint ranges in the set, in ascending order.
+func (set DateRangeSet) Ranges() []DateRange {
+	return slices.Clone(set.ranges)
+}
+
+// Len returns the number of disjoint ranges in the set.
+func (set DateRangeSet) Len() int {
+	return len(set.ranges)
+}
+
+// IsEmpty returns true if the set contains no dates.
+func (set DateRangeSet) IsEmpty() bool {
+	return len(set.ranges) == 0
+}
+
+// Days returns the total number of dates in the set.
+func (set DateRangeSet) Days() PeriodOfDays {
+	var n PeriodOfDays
+	for _, r := range set.ranges {
+		n += r.days
+	}
+	return n
+}
+
+// Bounds returns the smallest date range that contains every date in the set. For an
+// empty set, this is the zero DateRange.
+func (set DateRangeSet) Bounds() DateRange {
+	if len(set.ranges) == 0 {
+		return DateRange{}
+	}
+	return BetweenDates(set.ranges[0].start, set.ranges[len(set.ranges)-1].End())
+}
+
+// Contains tests whether the set contains a specified date.
+func (set DateRangeSet) Contains(d date.Date) bool {
+	i := sort.Search(len(set.ranges), func(i int) bool {
+		return d < set.ranges[i].End()
+	})
+	return i < len(set.ranges) && set.ranges[i].start <= d
+}
+
+// Union returns the set of dates that are in either set.
+func (set DateRangeSet) Union(other DateRangeSet) DateRangeSet {
+	ranges := make([]DateRange, 0, len(set.ranges)+len(other.ranges))
+	ranges = append(ranges, set.ranges...)
+	ranges = append(ranges, other.ranges...)
+	return DateRangeSet{ranges: normaliseRanges(ranges)}
+}
+
+// Intersect returns the set of dates that are in both sets.
+func (set DateRangeSet) Intersect(other DateRangeSet) DateRangeSet {
+	var result []DateRange
+	a, b := set.ranges, other.ranges
+	for len(a) > 0 && len(b) > 0 {
+		if r := a[0].Intersect(b[0]); !r.IsEmpty() {
+			result = append(result, r)
+		}
+		if a[0].End() < b[0].End() {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return DateRangeSet{ranges: result}
+}
+
+// Difference returns the set of dates that are in this set but not in the other.
+func (set DateRangeSet) Difference(other DateRangeSet) DateRangeSet {
+	return set.Intersect(other.Complement(set.Bounds()))
+}
+
+// Complement returns the dates within a bounding range that are not in the set.
+func (set DateRangeSet) Complement(within DateRange) DateRangeSet {
+	var result []DateRange
+	from := within.start
+	for _, r := range set.ranges {
+		if r.start >= within.End() {
+			break
+		}
+		if from < r.start {
+			result = append(result, BetweenDates(from, r.start))
+		}
+		from = max(from, r.End())
+	}
+	if from < within.End() {
+		result = append(result, BetweenDates(from, within.End()))
+	}
+	return DateRangeSet{ranges: result}
+}
+
+// FirstGap finds the earliest run of at least n consecutive dates within a bounding range
+// that are not in the set. The whole of the run is returned, which may be longer than n days.
+// The flag is false if there is no such run.
+func (set DateRangeSet) FirstGap(within DateRange, n PeriodOfDays) (DateRange, bool) {
+	for _, r := range set.Complement(within).ranges {
+		if r.days >= n {
+			return r, true
+		}
+	}
+	return DateRange{}, false
+}
+
+// String formats the set as a comma-separated list of ranges. Each range is written
+// as its first and last dates (inclusive) separated by '/', or as a single date if
+// the range is one day long, e.g. "2024-03-01/2024-03-07,2024-03-10".
+func (set DateRangeSet) String() string {
+	buf := &strings.Builder{}
+	for i, r := range set.ranges {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(r.start.String())
+		if r.days > 1 {
+			buf.WriteByte('/')
+			buf.WriteString(r.Last().String())
+		}
+	}
+	return buf.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that
+// of String. This also provides JSON encoding as a string.
+func (set DateRangeSet) MarshalText() ([]byte, error) {
+	return []byte(set.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface; the format is that
+// of String. The ranges need not be in order and may overlap.
+func (set *DateRangeSet) UnmarshalText(text []byte) error {
+	s, err := ParseDateRangeSet(string(text))
+	if err != nil {
+		return err
+	}
+	*set = s
+	return nil
+}
+
+// ParseDateRangeSet parses a set in the format produced by String. The ranges need not
+// be in order and may overlap. Spaces around each range are ignored. Besides single dates,
+// each range may be in any of the forms accepted by ParseDateRange with InclusiveEnd.
+func ParseDateRangeSet(text string) (DateRangeSet, error) {
+	if strings.TrimSpace(text) == "" {
+		return DateRangeSet{}, nil
+	}
+
+	var ranges []DateRange
+	for _, item := range strings.Split(text, ",") {
+		item = strings.TrimSpace(item)
+		if strings.IndexByte(item, '/') >= 0 {
+			r, err := ParseDateRange(item, InclusiveEnd)
+			if err != nil {
+				return DateRangeSet{}, fmt.Errorf("timespan.ParseDateRangeSet: %w", err)
+			}
+			ranges = append(ranges, r)
+		} else {
+			d, err := date.ParseISO(item)
+			if err != nil {
+				return DateRangeSet{}, fmt.Errorf("timespan.ParseDateRangeSet: %w", err)
+			}
+			ranges = append(ranges, OneDayRange(d))
+		}
+	}
+	return DateRangeSet{ranges: normaliseRanges(ranges)}, nil
+}
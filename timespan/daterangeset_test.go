@@ -0,0 +1,163 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func TestNewDateRangeSet(t *testing.T) {
+	cases := []struct {
+		in  []DateRange
+		exp string
+	}{
+		{in: nil, exp: ""},
+		{in: []DateRange{EmptyRange(d0325)}, exp: ""},
+		{in: []DateRange{OneDayRange(d0325)}, exp: "2015-03-25"},
+		{in: []DateRange{BetweenDates(d0327, d0329), BetweenDates(d0320, d0321)}, exp: "2015-03-20,2015-03-27/2015-03-28"},
+		{in: []DateRange{BetweenDates(d0325, d0327), BetweenDates(d0327, d0329)}, exp: "2015-03-25/2015-03-28"},
+		{in: []DateRange{BetweenDates(d0325, d0330), BetweenDates(d0326, d0328)}, exp: "2015-03-25/2015-03-29"},
+		{in: []DateRange{BetweenDates(d0325, d0328), BetweenDates(d0326, d0401), BetweenDates(d0320, d0321)}, exp: "2015-03-20,2015-03-25/2015-03-31"},
+	}
+
+	for i, c := range cases {
+		s := NewDateRangeSet(c.in...)
+		isEq(t, i, s.String(), c.exp)
+		isEq(t, i, s.IsEmpty(), c.exp == "")
+	}
+}
+
+func TestDateRangeSetQueries(t *testing.T) {
+	s := NewDateRangeSet(BetweenDates(d0320, d0321), BetweenDates(d0325, d0328), BetweenDates(d0401, d0404))
+
+	isEq(t, 0, s.Len(), 3)
+	isEq(t, 0, s.Days(), PeriodOfDays(7))
+	isEq(t, 0, s.Bounds(), BetweenDates(d0320, d0404))
+	isEq(t, 0, DateRangeSet{}.Bounds().IsZero(), true)
+
+	cases := []struct {
+		d   Date
+		exp bool
+	}{
+		{d: d0320, exp: true},
+		{d: d0321},
+		{d: d0325, exp: true},
+		{d: d0327, exp: true},
+		{d: d0328},
+		{d: d0331},
+		{d: d0401, exp: true},
+		{d: d0403, exp: true},
+		{d: d0404},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, s.Contains(c.d), c.exp, c.d)
+	}
+
+	// Ranges returns a copy
+	r := s.Ranges()
+	r[0] = OneDayRange(d1025)
+	isEq(t, 0, s.Contains(d1025), false)
+}
+
+func TestDateRangeSetAlgebra(t *testing.T) {
+	a := NewDateRangeSet(BetweenDates(d0320, d0326), BetweenDates(d0328, d0402))
+	b := NewDateRangeSet(BetweenDates(d0325, d0329), BetweenDates(d0401, d0404))
+	d0310 := New(2015, time.March, 10)
+
+	isEq(t, 0, a.Union(b).String(), "2015-03-20/2015-04-03")
+	isEq(t, 0, a.Intersect(b).String(), "2015-03-25,2015-03-28,2015-04-01")
+	isEq(t, 0, b.Intersect(a).String(), "2015-03-25,2015-03-28,2015-04-01")
+	isEq(t, 0, a.Difference(b).String(), "2015-03-20/2015-03-24,2015-03-29/2015-03-31")
+	isEq(t, 0, b.Difference(a).String(), "2015-03-26/2015-03-27,2015-04-02/2015-04-03")
+	isEq(t, 0, a.Difference(DateRangeSet{}).String(), a.String())
+	isEq(t, 0, DateRangeSet{}.Difference(a).IsEmpty(), true)
+	isEq(t, 0, a.Intersect(DateRangeSet{}).IsEmpty(), true)
+
+	isEq(t, 0, a.Complement(BetweenDates(d0321, d0408)).String(), "2015-03-26/2015-03-27,2015-04-02/2015-04-07")
+	isEq(t, 0, a.Complement(BetweenDates(d0310, d0320)).String(), "2015-03-10/2015-03-19")
+	isEq(t, 0, a.Complement(BetweenDates(d0321, d0325)).IsEmpty(), true)
+	isEq(t, 0, DateRangeSet{}.Complement(OneDayRange(d0325)).String(), "2015-03-25")
+
+	// the days of a set and its complement add up to the bounding range
+	w := BetweenDates(d0310, d0501)
+	isEq(t, 0, a.Days()+a.Complement(w).Days(), w.Days())
+	isEq(t, 0, a.Intersect(a.Complement(w)).IsEmpty(), true)
+}
+
+func TestDateRangeSetFirstGap(t *testing.T) {
+	s := NewDateRangeSet(BetweenDates(d0320, d0325), BetweenDates(d0326, d0330), BetweenDates(d0401, d0404))
+	w := BetweenDates(d0320, d0410)
+
+	cases := []struct {
+		n      PeriodOfDays
+		exp    DateRange
+		exists bool
+	}{
+		{n: 1, exp: OneDayRange(d0325), exists: true},
+		{n: 2, exp: BetweenDates(d0330, d0401), exists: true},
+		{n: 3, exp: BetweenDates(d0404, d0410), exists: true},
+		{n: 6, exp: BetweenDates(d0404, d0410), exists: true},
+		{n: 7},
+	}
+
+	for i, c := range cases {
+		r, ok := s.FirstGap(w, c.n)
+		isEq(t, i, ok, c.exists)
+		isEq(t, i, r, c.exp)
+	}
+}
+
+func TestDateRangeSetText(t *testing.T) {
+	cases := []struct {
+		in, exp string
+	}{
+		{in: "", exp: ""},
+		{in: "2015-03-25", exp: "2015-03-25"},
+		{in: "2015-03-25/2015-03-25", exp: "2015-03-25"},
+		{in: " 2015-04-01/2015-04-03 , 2015-03-25/2015-03-31", exp: "2015-03-25/2015-04-03"},
+		{in: "2015-03-20,2015-03-22,2015-03-21", exp: "2015-03-20/2015-03-22"},
+	}
+
+	for i, c := range cases {
+		var s DateRangeSet
+		err := s.UnmarshalText([]byte(c.in))
+		isEq(t, i, err, nil)
+		b, _ := s.MarshalText()
+		isEq(t, i, string(b), c.exp)
+	}
+
+	for i, c := range []string{"x", "2015-03-25/", "2015-03-25/2015-03-20", "2015-03-25,,2015-03-27"} {
+		_, err := ParseDateRangeSet(c)
+		isEq(t, i, err != nil, true, c)
+	}
+}
+
+func TestDateRangeSetJSON(t *testing.T) {
+	type config struct {
+		Blackout DateRangeSet `json:"blackout"`
+	}
+
+	c1 := config{Blackout: NewDateRangeSet(BetweenDates(d0325, d0328), OneDayRange(d0401))}
+	b, err := json.Marshal(c1)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), `{"blackout":"2015-03-25/2015-03-27,2015-04-01"}`)
+
+	var c2 config
+	err = json.Unmarshal(b, &c2)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, fmt.Sprint(c2.Blackout.Ranges()), fmt.Sprint(c1.Blackout.Ranges()))
+}
+
+func TestParseDateRangeSetISOForms(t *testing.T) {
+	s, err := ParseDateRangeSet("2015-03-20/P3D, 2015-03-25/27, P2D/2015-04-02")
+	isEq(t, 0, err, nil)
+	isEq(t, 0, s.String(), "2015-03-20/2015-03-22,2015-03-25/2015-03-27,2015-04-01/2015-04-02")
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/period"
+)
+
+// EndForm specifies how the end of a date range is written in ISO 8601 interval text.
+// ISO 8601 does not settle whether the second date of "2024-02-15/2024-03-14" is the
+// last date in the range or the date after it, so both are supported.
+type EndForm int
+
+const (
+	// InclusiveEnd writes the last date in the range, so "2024-03-01/2024-03-31" is
+	// the whole of March. This is the usual reading of date intervals.
+	InclusiveEnd EndForm = iota
+
+	// ExclusiveEnd writes the date after the last date, matching the half-open End()
+	// of DateRange, so "2024-03-01/2024-04-01" is the whole of March.
+	ExclusiveEnd
+)
+
+// FormatISO formats the date range as an ISO 8601 interval of two dates separated by '/',
+// such as "2024-02-15/2024-03-14", using the specified form for the end date.
+//
+// An empty range cannot be written with an inclusive end date, so it is written as
+// its start date and a zero period, e.g. "2024-02-15/P0D".
+func (dateRange DateRange) FormatISO(end EndForm) string {
+	if end == ExclusiveEnd {
+		return dateRange.start.String() + "/" + dateRange.End().String()
+	}
+	if dateRange.days == 0 {
+		return dateRange.start.String() + "/P0D"
+	}
+	return dateRange.start.String() + "/" + dateRange.Last().String()
+}
+
+// MarshalText formats the date range as an ISO 8601 interval with an inclusive end date,
+// e.g. "2024-02-15/2024-03-14". Use ExclusiveDateRange for the exclusive form.
+// This implements the encoding.TextMarshaler interface.
+func (dateRange DateRange) MarshalText() ([]byte, error) {
+	return []byte(dateRange.FormatISO(InclusiveEnd)), nil
+}
+
+// UnmarshalText parses an ISO 8601 interval using ParseDateRange with InclusiveEnd.
+// This implements the encoding.TextUnmarshaler interface.
+func (dateRange *DateRange) UnmarshalText(text []byte) (err error) {
+	*dateRange, err = ParseDateRange(string(text), InclusiveEnd)
+	return err
+}
+
+// ExclusiveDateRange is a DateRange whose text form has an exclusive end date, i.e. the
+// date after the last date, e.g. "2024-03-01/2024-04-01" for the whole of March. Use it
+// instead of DateRange for the fields of structs encoded as JSON, for example, when the
+// other party expects that form.
+type ExclusiveDateRange struct {
+	DateRange
+}
+
+// String formats the date range as per FormatISO with ExclusiveEnd.
+func (dateRange ExclusiveDateRange) String() string {
+	return dateRange.FormatISO(ExclusiveEnd)
+}
+
+// MarshalText formats the date range as an ISO 8601 interval with an exclusive end date.
+// This implements the encoding.TextMarshaler interface.
+func (dateRange ExclusiveDateRange) MarshalText() ([]byte, error) {
+	return []byte(dateRange.FormatISO(ExclusiveEnd)), nil
+}
+
+// UnmarshalText parses an ISO 8601 interval using ParseDateRange with ExclusiveEnd.
+// This implements the encoding.TextUnmarshaler interface.
+func (dateRange *ExclusiveDateRange) UnmarshalText(text []byte) (err error) {
+	dateRange.DateRange, err = ParseDateRange(string(text), ExclusiveEnd)
+	return err
+}
+
+// MustParseDateRange is as per ParseDateRange except that it panics if the string cannot be parsed.
+// This is intended for setup code; don't use it for user inputs.
+func MustParseDateRange(text string, end EndForm) DateRange {
+	dr, err := ParseDateRange(text, end)
+	if err != nil {
+		panic(err)
+	}
+	return dr
+}
+
+// ParseDateRange parses an ISO 8601 interval of dates. The string must contain one of
+//
+//	start "/" end       e.g. 2024-02-15/2024-03-14
+//	start "/" period    e.g. 2024-02-15/P1M
+//	period "/" end      e.g. P7D/2024-03-14
+//
+// The end date is interpreted using the specified form: with InclusiveEnd, it is the
+// last date in the range; with ExclusiveEnd, it is the date after the last date.
+//
+// A period is the length of the range, so "2024-02-15/P1M" is from 15th February up to
+// but excluding 15th March, whichever end form is used. Any time component of the period
+// is ignored. Negative periods are not allowed.
+//
+// The end date may be abbreviated by omitting its leading components, which are then
+// taken from the start date: "2024-02-15/03-14" and "2024-02-15/20" are allowed.
+//
+// The dates are parsed with date.ParseISO.
+func ParseDateRange(text string, end EndForm) (DateRange, error) {
+	first, second, found := strings.Cut(text, "/")
+	if !found {
+		return DateRange{}, fmt.Errorf("timespan.ParseDateRange: cannot parse %q because there is no separator '/'", text)
+	}
+
+	dr, err := parseDateRange(first, second, end)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("timespan.ParseDateRange: cannot parse %q: %w", text, err)
+	}
+	return dr, nil
+}
+
+func parseDateRange(first, second string, end EndForm) (DateRange, error) {
+	if isPeriod(first) {
+		p, err := parseNonNegativePeriod(first)
+		if err != nil {
+			return DateRange{}, err
+		}
+
+		last, err := date.ParseISO(second)
+		if err != nil {
+			return DateRange{}, err
+		}
+
+		exclusive := exclusiveEnd(last, end)
+		return BetweenDates(exclusive.AddPeriod(p.Negate()), exclusive), nil
+	}
+
+	start, err := date.ParseISO(first)
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	if isPeriod(second) {
+		p, err := parseNonNegativePeriod(second)
+		if err != nil {
+			return DateRange{}, err
+		}
+		return BetweenDates(start, start.AddPeriod(p)), nil
+	}
+
+	last, err := parseAbbreviatedDate(second, start)
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	exclusive := exclusiveEnd(last, end)
+	if exclusive < start {
+		return DateRange{}, fmt.Errorf("the end is before the start")
+	}
+	return BetweenDates(start, exclusive), nil
+}
+
+func exclusiveEnd(d date.Date, end EndForm) date.Date {
+	if end == InclusiveEnd {
+		return d + 1
+	}
+	return d
+}
+
+func isPeriod(s string) bool {
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "+P") || strings.HasPrefix(s, "-P")
+}
+
+func parseNonNegativePeriod(s string) (period.Period, error) {
+	p, err := period.Parse(s)
+	if err != nil {
+		return period.Period{}, err
+	}
+	if p.IsNegative() {
+		return period.Period{}, fmt.Errorf("the period %s is negative", s)
+	}
+	return p, nil
+}
+
+// parseAbbreviatedDate parses a date from which the leading components may have been
+// omitted, i.e. "MM-DD" or "DD"; these are filled in from a reference date.
+func parseAbbreviatedDate(s string, ref date.Date) (date.Date, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) > 2 || len(parts[0]) != 2 {
+		return date.ParseISO(s)
+	}
+
+	y, m, _ := ref.Date()
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || len(part) != 2 {
+			return 0, fmt.Errorf("invalid abbreviated date %q", s)
+		}
+		nums[i] = n
+	}
+
+	d := nums[len(nums)-1]
+	if len(nums) == 2 {
+		if nums[0] < 1 || nums[0] > 12 {
+			return 0, fmt.Errorf("invalid month in %q", s)
+		}
+		m = time.Month(nums[0])
+	}
+
+	result := date.New(y, m, d)
+	if _, _, rd := result.Date(); rd != d || d < 1 {
+		return 0, fmt.Errorf("invalid day in %q", s)
+	}
+	return result, nil
+}
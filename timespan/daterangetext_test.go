@@ -0,0 +1,149 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func TestFormatISO(t *testing.T) {
+	cases := []struct {
+		dr                   DateRange
+		inclusive, exclusive string
+	}{
+		{dr: BetweenDates(d0320, d0401), inclusive: "2015-03-20/2015-03-31", exclusive: "2015-03-20/2015-04-01"},
+		{dr: OneDayRange(d0320), inclusive: "2015-03-20/2015-03-20", exclusive: "2015-03-20/2015-03-21"},
+		{dr: EmptyRange(d0320), inclusive: "2015-03-20/P0D", exclusive: "2015-03-20/2015-03-20"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.dr.FormatISO(InclusiveEnd), c.inclusive)
+		isEq(t, i, c.dr.FormatISO(ExclusiveEnd), c.exclusive)
+		isEq(t, i, MustParseDateRange(c.inclusive, InclusiveEnd), c.dr, c.inclusive)
+		isEq(t, i, MustParseDateRange(c.exclusive, ExclusiveEnd), c.dr, c.exclusive)
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	feb15 := New(2024, time.February, 15)
+
+	cases := []struct {
+		in  string
+		end EndForm
+		exp DateRange
+	}{
+		{in: "2024-02-15/2024-03-14", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 15))},
+		{in: "2024-02-15/2024-03-14", end: ExclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 14))},
+		{in: "20240215/20240314", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 15))},
+		{in: "2024-02-15/03-14", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 15))},
+		{in: "2024-02-15/20", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.February, 21))},
+		{in: "2024-02-15/20", end: ExclusiveEnd, exp: BetweenDates(feb15, New(2024, time.February, 20))},
+		{in: "2024-02-15/P1M", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 15))},
+		{in: "2024-02-15/P1M", end: ExclusiveEnd, exp: BetweenDates(feb15, New(2024, time.March, 15))},
+		{in: "2024-02-15/P2W", end: InclusiveEnd, exp: BetweenDates(feb15, New(2024, time.February, 29))},
+		{in: "2024-02-15/P0D", end: InclusiveEnd, exp: EmptyRange(feb15)},
+		{in: "P7D/2024-03-14", end: InclusiveEnd, exp: BetweenDates(New(2024, time.March, 8), New(2024, time.March, 15))},
+		{in: "P7D/2024-03-14", end: ExclusiveEnd, exp: BetweenDates(New(2024, time.March, 7), New(2024, time.March, 14))},
+		{in: "P1M/2024-03-31", end: InclusiveEnd, exp: NewMonthOf(2024, time.March)},
+		{in: "2024-02-15/2024-02-14", end: InclusiveEnd, exp: EmptyRange(feb15)},
+		{in: "2024-02-15/2024-02-15", end: ExclusiveEnd, exp: EmptyRange(feb15)},
+	}
+
+	for i, c := range cases {
+		dr, err := ParseDateRange(c.in, c.end)
+		isEq(t, i, err, nil, c.in)
+		isEq(t, i, dr, c.exp, c.in)
+	}
+}
+
+func TestParseDateRangeErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"2024-02-15",
+		"2024-02-15/",
+		"/2024-02-15",
+		"2024-02-15/2024-02-13",
+		"2024-02-15/02-13",
+		"2024-02-15/-P1D",
+		"-P1D/2024-02-15",
+		"2024-02-15/PXD",
+		"2024-02-15/13-01",
+		"2024-02-15/02-30",
+		"2024-02-15/31",
+		"2024-02-15/1",
+		"2024-02-15/x",
+		"P1D/P1D",
+	}
+
+	for i, c := range cases {
+		_, err := ParseDateRange(c, InclusiveEnd)
+		isEq(t, i, err != nil, true, c)
+	}
+}
+
+func TestDateRangeMarshalText(t *testing.T) {
+	dr := BetweenDates(d0320, d0401)
+
+	b, err := dr.MarshalText()
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), "2015-03-20/2015-03-31")
+
+	var dr2 DateRange
+	err = dr2.UnmarshalText(b)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, dr2, dr)
+
+	ex := ExclusiveDateRange{DateRange: dr}
+	b, err = ex.MarshalText()
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), "2015-03-20/2015-04-01")
+	isEq(t, 0, ex.String(), "2015-03-20/2015-04-01")
+
+	var ex2 ExclusiveDateRange
+	err = ex2.UnmarshalText(b)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, ex2.DateRange, dr)
+}
+
+func TestDateRangeJSON(t *testing.T) {
+	type booking struct {
+		Dates DateRange `json:"dates"`
+	}
+
+	b, err := json.Marshal(booking{Dates: NewMonthOf(2024, time.February)})
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), `{"dates":"2024-02-01/2024-02-29"}`)
+
+	var v booking
+	err = json.Unmarshal([]byte(`{"dates":"2024-02-01/P1M"}`), &v)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, v.Dates, NewMonthOf(2024, time.February))
+
+	err = json.Unmarshal([]byte(`{"dates":"2024-02-01"}`), &v)
+	isEq(t, 0, err != nil, true)
+}
+
+func TestExclusiveDateRangeJSON(t *testing.T) {
+	// the two forms can be used side by side
+	type booking struct {
+		Stay     DateRange          `json:"stay"`
+		Checkout ExclusiveDateRange `json:"checkout"`
+	}
+
+	feb := NewMonthOf(2024, time.February)
+	b, err := json.Marshal(booking{Stay: feb, Checkout: ExclusiveDateRange{feb}})
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), `{"stay":"2024-02-01/2024-02-29","checkout":"2024-02-01/2024-03-01"}`)
+
+	var v booking
+	err = json.Unmarshal(b, &v)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, v.Stay, feb)
+	isEq(t, 0, v.Checkout.DateRange, feb)
+}
@@ -0,0 +1,444 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/rickb777/date/v2"
+)
+
+// DateSet is a set of dates held as a compressed bitmap, in the style of a roaring bitmap.
+// The dates are grouped into blocks of 1024 days. Each block that contains at least one
+// date holds its dates in whichever is smallest of a sorted array (two bytes per date),
+// a list of runs of consecutive dates (four bytes per run) or a bitmap (128 bytes). This
+// makes membership tests fast and keeps the set small, whether the dates are sparse, such
+// as holidays, or dense, such as availability.
+//
+// DateSet is mutable: Add and Remove alter the set. The set operations return new sets.
+// The zero value is an empty set ready to use. A DateSet must not be copied after first
+// use; use Clone instead.
+type DateSet struct {
+	chunks []dateChunk // ordered by key; none are empty
+}
+
+// NewDateSet constructs a set containing the given dates.
+func NewDateSet(dates ...date.Date) *DateSet {
+	set := &DateSet{}
+	for _, d := range dates {
+		set.Add(d)
+	}
+	return set
+}
+
+// find gets the index of the chunk with a key, or of where it would be inserted.
+func (set *DateSet) find(key int64) (int, bool) {
+	lo, hi := 0, len(set.chunks)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if set.chunks[mid].key < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(set.chunks) && set.chunks[lo].key == key
+}
+
+// Add adds a date to the set.
+func (set *DateSet) Add(d date.Date) {
+	if set.Contains(d) {
+		return
+	}
+	key, offset := splitDate(d)
+	set.alter(key, func(b *chunkBitmap) { b[offset/64] |= 1 << (offset % 64) })
+}
+
+// AddRange adds every date in a date range to the set.
+func (set *DateSet) AddRange(dateRange DateRange) {
+	for from := dateRange.start; from < dateRange.End(); {
+		key, lo := splitDate(from)
+		hi := min(chunkDays, lo+int(dateRange.End()-from))
+		set.alter(key, func(b *chunkBitmap) { b.setRange(lo, hi) })
+		from += date.Date(hi - lo)
+	}
+}
+
+// Remove removes a date from the set. It has no effect if the date is not in the set.
+func (set *DateSet) Remove(d date.Date) {
+	if !set.Contains(d) {
+		return
+	}
+	key, offset := splitDate(d)
+	set.alter(key, func(b *chunkBitmap) { b[offset/64] &^= 1 << (offset % 64) })
+}
+
+// alter changes the bitmap of a chunk, which need not exist, then rebuilds the chunk
+// with its smallest container, or deletes it if it has become empty.
+func (set *DateSet) alter(key int64, change func(*chunkBitmap)) {
+	i, found := set.find(key)
+	var b chunkBitmap
+	if found {
+		b = set.chunks[i].toBitmap()
+	}
+	change(&b)
+
+	c := newChunk(key, &b)
+	switch {
+	case c.isEmpty() && found:
+		set.chunks = slices.Delete(set.chunks, i, i+1)
+	case c.isEmpty():
+	case found:
+		set.chunks[i] = c
+	default:
+		set.chunks = slices.Insert(set.chunks, i, c)
+	}
+}
+
+// Contains tests whether the set contains a specified date.
+func (set *DateSet) Contains(d date.Date) bool {
+	key, offset := splitDate(d)
+	i, found := set.find(key)
+	return found && set.chunks[i].contains(offset)
+}
+
+// IsEmpty returns true if the set contains no dates.
+func (set *DateSet) IsEmpty() bool {
+	return len(set.chunks) == 0
+}
+
+// Len returns the number of dates in the set.
+func (set *DateSet) Len() int {
+	n := 0
+	for i := range set.chunks {
+		n += set.chunks[i].count()
+	}
+	return n
+}
+
+// CountIn returns the number of dates in the set that are also in a date range.
+func (set *DateSet) CountIn(dateRange DateRange) int {
+	if dateRange.days == 0 {
+		return 0
+	}
+
+	firstKey, firstOffset := splitDate(dateRange.start)
+	lastKey, lastOffset := splitDate(dateRange.Last())
+
+	n := 0
+	i, _ := set.find(firstKey)
+	for ; i < len(set.chunks) && set.chunks[i].key <= lastKey; i++ {
+		lo, hi := 0, chunkDays
+		if set.chunks[i].key == firstKey {
+			lo = firstOffset
+		}
+		if set.chunks[i].key == lastKey {
+			hi = lastOffset + 1
+		}
+		n += set.chunks[i].countBetween(lo, hi)
+	}
+	return n
+}
+
+// Clone returns a copy of the set. The copy shares the containers of the original, which
+// are never altered.
+func (set *DateSet) Clone() *DateSet {
+	return &DateSet{chunks: slices.Clone(set.chunks)}
+}
+
+// Equal tests whether two sets contain the same dates.
+func (set *DateSet) Equal(other *DateSet) bool {
+	return slices.EqualFunc(set.chunks, other.chunks, func(a, b dateChunk) bool { return a.equal(&b) })
+}
+
+// Union returns the set of dates that are in either set.
+func (set *DateSet) Union(other *DateSet) *DateSet {
+	return set.merge(other, true, true, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersect returns the set of dates that are in both sets.
+func (set *DateSet) Intersect(other *DateSet) *DateSet {
+	return set.merge(other, false, false, func(a, b uint64) uint64 { return a & b })
+}
+
+// Difference returns the set of dates that are in this set but not in the other.
+func (set *DateSet) Difference(other *DateSet) *DateSet {
+	return set.merge(other, true, false, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// merge combines the chunks of two sets. Chunks that are only in one of the sets are
+// kept if the corresponding flag is set; chunks in both are combined word by word as
+// bitmaps.
+func (set *DateSet) merge(other *DateSet, keepA, keepB bool, op func(a, b uint64) uint64) *DateSet {
+	result := &DateSet{}
+	a, b := set.chunks, other.chunks
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || len(a) > 0 && a[0].key < b[0].key:
+			if keepA {
+				result.chunks = append(result.chunks, a[0])
+			}
+			a = a[1:]
+
+		case len(a) == 0 || b[0].key < a[0].key:
+			if keepB {
+				result.chunks = append(result.chunks, b[0])
+			}
+			b = b[1:]
+
+		default:
+			ba, bb := a[0].toBitmap(), b[0].toBitmap()
+			for j := range ba {
+				ba[j] = op(ba[j], bb[j])
+			}
+			if c := newChunk(a[0].key, &ba); !c.isEmpty() {
+				result.chunks = append(result.chunks, c)
+			}
+			a, b = a[1:], b[1:]
+		}
+	}
+	return result
+}
+
+// All returns an iterator over the dates in the set, in ascending order.
+func (set *DateSet) All() iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		for i := range set.chunks {
+			base := date.Date(set.chunks[i].key << chunkShift)
+			for offset := range set.chunks[i].offsets() {
+				if !yield(base + date.Date(offset)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DateRangeSet converts the set to a DateRangeSet, which holds runs of consecutive dates
+// as date ranges.
+func (set *DateSet) DateRangeSet() DateRangeSet {
+	var ranges []DateRange
+	for d := range set.All() {
+		if n := len(ranges); n > 0 && ranges[n-1].End() == d {
+			ranges[n-1].days++
+		} else {
+			ranges = append(ranges, OneDayRange(d))
+		}
+	}
+	return DateRangeSet{ranges: ranges}
+}
+
+// String formats the set as a comma-separated list of runs of consecutive dates, as per
+// DateRangeSet.String, e.g. "2024-03-01/2024-03-07,2024-03-10".
+func (set *DateSet) String() string {
+	return set.DateRangeSet().String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface; the format is that
+// of String. This also provides JSON encoding as a string.
+func (set *DateSet) MarshalText() ([]byte, error) {
+	return []byte(set.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is parsed
+// using ParseDateRangeSet.
+func (set *DateSet) UnmarshalText(text []byte) error {
+	drs, err := ParseDateRangeSet(string(text))
+	if err != nil {
+		return err
+	}
+
+	*set = DateSet{}
+	for _, r := range drs.ranges {
+		set.AddRange(r)
+	}
+	return nil
+}
+
+// The versions of the binary encoding. Version 1 held every block as a bitmap; it can
+// still be read.
+const (
+	dateSetBitmapsVersion = 1
+	dateSetVersion        = 2
+)
+
+// The kinds of container in the binary encoding.
+const (
+	arrayKind byte = iota
+	runsKind
+	bitmapKind
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The encoding is a
+// version byte, then the number of blocks, then for each block its key, the kind of its
+// container and the container: the number of dates and their offsets, the number of runs
+// and the first and last offset of each, or the bitmap.
+func (set *DateSet) MarshalBinary() ([]byte, error) {
+	b := []byte{dateSetVersion}
+	b = binary.AppendUvarint(b, uint64(len(set.chunks)))
+	for i := range set.chunks {
+		c := &set.chunks[i]
+		b = binary.AppendVarint(b, c.key)
+		switch {
+		case c.bitmap != nil:
+			b = append(b, bitmapKind)
+			b = appendBitmap(b, c.bitmap)
+		case c.runs != nil:
+			b = append(b, runsKind)
+			b = binary.AppendUvarint(b, uint64(len(c.runs)))
+			for _, r := range c.runs {
+				b = binary.LittleEndian.AppendUint16(b, r.first)
+				b = binary.LittleEndian.AppendUint16(b, r.last)
+			}
+		default:
+			b = append(b, arrayKind)
+			b = binary.AppendUvarint(b, uint64(len(c.array)))
+			for _, offset := range c.array {
+				b = binary.LittleEndian.AppendUint16(b, offset)
+			}
+		}
+	}
+	return b, nil
+}
+
+func appendBitmap(b []byte, bitmap *chunkBitmap) []byte {
+	for _, w := range bitmap {
+		b = binary.LittleEndian.AppendUint64(b, w)
+	}
+	return b
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. Each block is held
+// in its smallest container, whichever container it was encoded with.
+func (set *DateSet) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("timespan.DateSet.UnmarshalBinary: no data")
+	}
+	version := data[0]
+	if version != dateSetBitmapsVersion && version != dateSetVersion {
+		return fmt.Errorf("timespan.DateSet.UnmarshalBinary: unsupported version %d", version)
+	}
+
+	n, k := binary.Uvarint(data[1:])
+	if k <= 0 {
+		return errors.New("timespan.DateSet.UnmarshalBinary: invalid length")
+	}
+	d := &dateSetDecoder{data: data[1+k:]}
+
+	var chunks []dateChunk
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		key := d.varint()
+		kind := bitmapKind
+		if version == dateSetVersion {
+			kind = d.byte()
+		}
+
+		var b chunkBitmap
+		switch kind {
+		case arrayKind:
+			for j := d.count(2); j > 0; j-- {
+				offset := d.offset()
+				b[offset/64] |= 1 << (offset % 64)
+			}
+		case runsKind:
+			for j := d.count(4); j > 0; j-- {
+				first, last := d.offset(), d.offset()
+				if first > last {
+					d.fail("a run ends before it starts")
+				}
+				b.setRange(first, last+1)
+			}
+		case bitmapKind:
+			for j := range b {
+				b[j] = d.uint64()
+			}
+		default:
+			d.fail(fmt.Sprintf("unknown container %d", kind))
+		}
+
+		if len(chunks) > 0 && chunks[len(chunks)-1].key >= key {
+			d.fail("blocks are out of order")
+		}
+		if c := newChunk(key, &b); !c.isEmpty() {
+			chunks = append(chunks, c)
+		}
+	}
+
+	if d.err != nil {
+		return fmt.Errorf("timespan.DateSet.UnmarshalBinary: %w", d.err)
+	}
+	if len(d.data) > 0 {
+		return fmt.Errorf("timespan.DateSet.UnmarshalBinary: %d unexpected trailing bytes", len(d.data))
+	}
+
+	set.chunks = chunks
+	return nil
+}
+
+// dateSetDecoder reads the binary encoding of a DateSet. After the first error, it reads
+// only zeros.
+type dateSetDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *dateSetDecoder) fail(msg string) {
+	if d.err == nil {
+		d.err = errors.New(msg)
+	}
+}
+
+func (d *dateSetDecoder) take(n int) []byte {
+	if d.err != nil || len(d.data) < n {
+		d.fail("data is truncated")
+		return make([]byte, n)
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *dateSetDecoder) byte() byte {
+	return d.take(1)[0]
+}
+
+func (d *dateSetDecoder) uint64() uint64 {
+	return binary.LittleEndian.Uint64(d.take(8))
+}
+
+func (d *dateSetDecoder) varint() int64 {
+	v, k := binary.Varint(d.data)
+	if d.err != nil || k <= 0 {
+		d.fail("data is truncated")
+		return 0
+	}
+	d.take(k)
+	return v
+}
+
+// count reads the number of items of a given size that follow, which must be present.
+func (d *dateSetDecoder) count(size int) int {
+	n, k := binary.Uvarint(d.data)
+	if d.err != nil || k <= 0 || n > uint64(len(d.data)-k)/uint64(size) {
+		d.fail("data is truncated")
+		return 0
+	}
+	d.take(k)
+	return int(n)
+}
+
+// offset reads the offset of a day in a block.
+func (d *dateSetDecoder) offset() int {
+	offset := int(binary.LittleEndian.Uint16(d.take(2)))
+	if offset >= chunkDays {
+		d.fail(fmt.Sprintf("offset %d is outside the block", offset))
+		return 0
+	}
+	return offset
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"testing"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func TestDateSetAddRemoveContains(t *testing.T) {
+	farPast := d0320 - 100000
+	set := NewDateSet(d0401, d0320, d0327, farPast)
+	set.Add(d0327)
+
+	isEq(t, 0, set.Len(), 4)
+	isEq(t, 0, set.IsEmpty(), false)
+	isEq(t, 0, slices.Equal(slices.Collect(set.All()), []Date{farPast, d0320, d0327, d0401}), true)
+
+	cases := []struct {
+		d   Date
+		exp bool
+	}{
+		{d: farPast, exp: true},
+		{d: farPast + 1},
+		{d: d0320, exp: true},
+		{d: d0321},
+		{d: d0327, exp: true},
+		{d: d0401, exp: true},
+		{d: d0402},
+		{d: -d0401},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, set.Contains(c.d), c.exp, c.d)
+	}
+
+	set.Remove(farPast)
+	set.Remove(d0321)
+	isEq(t, 0, set.Contains(farPast), false)
+	isEq(t, 0, set.Len(), 3)
+	isEq(t, 0, len(set.chunks), 1)
+
+	var zero DateSet
+	isEq(t, 0, zero.Contains(d0320), false)
+	isEq(t, 0, zero.IsEmpty(), true)
+	zero.Remove(d0320)
+	isEq(t, 0, zero.String(), "")
+}
+
+func TestDateSetAlgebra(t *testing.T) {
+	a := &DateSet{}
+	a.AddRange(BetweenDates(d0320, d0328))
+	a.Add(d0501)
+	b := &DateSet{}
+	b.AddRange(BetweenDates(d0325, d0402))
+	b.Add(d0320 + 5000)
+
+	isEq(t, 0, a.String(), "2015-03-20/2015-03-27,2015-05-01")
+	isEq(t, 0, a.Union(b).String(), "2015-03-20/2015-04-01,2015-05-01,2028-11-26")
+	isEq(t, 0, a.Intersect(b).String(), "2015-03-25/2015-03-27")
+	isEq(t, 0, a.Difference(b).String(), "2015-03-20/2015-03-24,2015-05-01")
+	isEq(t, 0, b.Difference(a).String(), "2015-03-28/2015-04-01,2028-11-26")
+	isEq(t, 0, a.Intersect(NewDateSet(d0403)).IsEmpty(), true)
+	isEq(t, 0, a.Union(b).Equal(b.Union(a)), true)
+	isEq(t, 0, a.Equal(b), false)
+
+	c := a.Clone()
+	c.Remove(d0320)
+	isEq(t, 0, a.Contains(d0320), true)
+	isEq(t, 0, c.Contains(d0320), false)
+
+	isEq(t, 0, a.DateRangeSet().String(), NewDateRangeSet(BetweenDates(d0320, d0328), OneDayRange(d0501)).String())
+}
+
+func TestDateSetCountIn(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	set := &DateSet{}
+	var dates []Date
+	for i := 0; i < 2000; i++ {
+		d := d0320 + Date(rnd.Intn(5000)-2500)
+		set.Add(d)
+		dates = append(dates, d)
+	}
+
+	for i := 0; i < 200; i++ {
+		r := DateRange{start: d0320 + Date(rnd.Intn(6000)-3000), days: PeriodOfDays(rnd.Intn(3000))}
+		exp := 0
+		for d := range set.All() {
+			if r.Contains(d) {
+				exp++
+			}
+		}
+		isEq(t, i, set.CountIn(r), exp, r)
+	}
+
+	isEq(t, 0, set.CountIn(EmptyRange(dates[0])), 0)
+	isEq(t, 0, set.CountIn(OneDayRange(dates[0])), 1)
+}
+
+func TestDateSetMarshal(t *testing.T) {
+	set := NewDateSet(d0320, d0321, d0321+1, d0401, d0320+3000, d0320-3000)
+
+	b, err := set.MarshalBinary()
+	isEq(t, 0, err, nil)
+	var u DateSet
+	isEq(t, 0, u.UnmarshalBinary(b), nil)
+	isEq(t, 0, u.Equal(set), true)
+
+	isEq(t, 0, u.UnmarshalBinary(nil) != nil, true)
+	isEq(t, 0, u.UnmarshalBinary([]byte{9, 0}) != nil, true)
+	isEq(t, 0, u.UnmarshalBinary(b[:len(b)-1]) != nil, true)
+	isEq(t, 0, u.UnmarshalBinary(append(b, 0)) != nil, true)
+
+	// version 1 held every block as a bitmap
+	key, _ := splitDate(d0320)
+	v1 := binary.AppendVarint([]byte{1, 1}, key)
+	v1 = appendBitmap(v1, &chunkBitmap{0b1011})
+	base := Date(key << chunkShift)
+	isEq(t, 0, u.UnmarshalBinary(v1), nil)
+	isEq(t, 0, u.Equal(NewDateSet(base, base+1, base+3)), true)
+	isEq(t, 0, len(u.chunks[0].array), 3)
+
+	// scattered dates take two bytes each, plus a few bytes for each block
+	isEq(t, 0, len(b) <= 2+len(set.chunks)*5+2*set.Len(), true, len(b))
+
+	block := binary.AppendVarint([]byte{dateSetVersion, 1}, key)
+	for i, bad := range [][]byte{
+		append(block, 9),                       // unknown container
+		append(block, arrayKind, 1, 0, 4),      // offset outside the block
+		append(block, runsKind, 1, 5, 0, 4, 0), // run ends before it starts
+		append(block, arrayKind, 3, 1, 0),      // truncated
+		append(block, bitmapKind, 1, 2),        // truncated
+	} {
+		isEq(t, i, u.UnmarshalBinary(bad) != nil, true)
+	}
+
+	empty, err := (&DateSet{}).MarshalBinary()
+	isEq(t, 0, err, nil)
+	isEq(t, 0, u.UnmarshalBinary(empty), nil)
+	isEq(t, 0, u.IsEmpty(), true)
+
+	j, err := json.Marshal(set)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(j), `"2007-01-01,2015-03-20/2015-03-22,2015-04-01,2023-06-06"`)
+
+	var v DateSet
+	isEq(t, 0, json.Unmarshal(j, &v), nil)
+	isEq(t, 0, v.Equal(set), true)
+	isEq(t, 0, v.UnmarshalText([]byte("2015-03-20/xyz")) != nil, true)
+}
+
+func TestDateSetContainers(t *testing.T) {
+	// the first block of dates from d0320 has its key
+	key, _ := splitDate(d0320)
+	base := Date(key << chunkShift)
+
+	holidays := NewDateSet(base+1, base+100, base+360, base+725)
+	season := &DateSet{}
+	season.AddRange(BetweenDates(base+100, base+300))
+	season.AddRange(BetweenDates(base+400, base+500))
+	busy := &DateSet{}
+	for d := base; d < base+chunkDays; d += 3 {
+		busy.Add(d)
+	}
+
+	cases := []struct {
+		set                *DateSet
+		array, runs, words int
+	}{
+		{set: holidays, array: 4},
+		{set: season, runs: 2},
+		{set: busy, words: chunkWords},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, len(c.set.chunks), 1)
+		chunk := c.set.chunks[0]
+		isEq(t, i, len(chunk.array), c.array)
+		isEq(t, i, len(chunk.runs), c.runs)
+		isEq(t, i, chunk.bitmap != nil, c.words > 0)
+
+		b, err := c.set.MarshalBinary()
+		isEq(t, i, err, nil)
+		var u DateSet
+		isEq(t, i, u.UnmarshalBinary(b), nil)
+		isEq(t, i, u.Equal(c.set), true)
+	}
+
+	// the containers change as dates are added and removed
+	for d := base + 700; d < base+900; d++ {
+		holidays.Add(d)
+	}
+	isEq(t, 0, len(holidays.chunks[0].runs), 4)
+	for d := base + 700; d < base+900; d += 2 {
+		holidays.Remove(d)
+	}
+	isEq(t, 0, holidays.chunks[0].bitmap != nil, true)
+	isEq(t, 0, holidays.Len(), 103)
+	for d := base + 701; d < base+900; d += 2 {
+		holidays.Remove(d)
+	}
+	isEq(t, 0, holidays.Equal(NewDateSet(base+1, base+100, base+360)), true)
+	isEq(t, 0, len(holidays.chunks[0].array), 3)
+}
+
+func TestDateSetAgainstMap(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	set := &DateSet{}
+	dates := map[Date]bool{}
+
+	// sparse dates, runs of dates and dense dates, to use every kind of container
+	for i := 0; i < 3000; i++ {
+		d := d0320 + Date(rnd.Intn(5000))
+		switch i % 3 {
+		case 0:
+			r := OneDayRange(d).ExtendBy(PeriodOfDays(rnd.Intn(200)))
+			set.AddRange(r)
+			for e := range r.Dates() {
+				dates[e] = true
+			}
+		case 1:
+			set.Add(d)
+			dates[d] = true
+		default:
+			set.Remove(d)
+			delete(dates, d)
+		}
+	}
+
+	var want []Date
+	for d := range dates {
+		want = append(want, d)
+	}
+	slices.Sort(want)
+	isEq(t, 0, slices.Equal(slices.Collect(set.All()), want), true)
+	isEq(t, 0, set.Len(), len(want))
+
+	for i := 0; i < 500; i++ {
+		d := d0320 + Date(rnd.Intn(6000)-500)
+		isEq(t, i, set.Contains(d), dates[d], d)
+
+		r := DateRange{start: d, days: PeriodOfDays(rnd.Intn(1500))}
+		n := 0
+		for e := range r.Dates() {
+			if dates[e] {
+				n++
+			}
+		}
+		isEq(t, i, set.CountIn(r), n, r)
+	}
+
+	// the set algebra agrees whatever the containers
+	var alternate []Date
+	for i := 0; i < len(want); i += 2 {
+		alternate = append(alternate, want[i])
+	}
+	other := NewDateSet(alternate...)
+	other.AddRange(BetweenDates(d0320+6000, d0320+7000))
+	isEq(t, 0, set.Intersect(other).Equal(NewDateSet(alternate...)), true)
+	isEq(t, 0, set.Union(other).Len(), len(want)+1000)
+	isEq(t, 0, set.Difference(other).Len(), len(want)-len(alternate))
+	isEq(t, 0, set.Difference(other).Union(set.Intersect(other)).Equal(set), true)
+}
+
+func benchmarkDates() []Date {
+	rnd := rand.New(rand.NewSource(1))
+	dates := make([]Date, 1000)
+	for i := range dates {
+		dates[i] = d0320 + Date(rnd.Intn(3650))
+	}
+	return dates
+}
+
+func BenchmarkDateSetContains(b *testing.B) {
+	dates := benchmarkDates()
+	set := NewDateSet(dates[:100]...)
+
+	b.ResetTimer()
+	n := 0
+	for i := 0; i < b.N; i++ {
+		if set.Contains(dates[i%len(dates)]) {
+			n++
+		}
+	}
+	sink = n
+}
+
+func BenchmarkMapContains(b *testing.B) {
+	dates := benchmarkDates()
+	set := map[Date]struct{}{}
+	for _, d := range dates[:100] {
+		set[d] = struct{}{}
+	}
+
+	b.ResetTimer()
+	n := 0
+	for i := 0; i < b.N; i++ {
+		if _, ok := set[dates[i%len(dates)]]; ok {
+			n++
+		}
+	}
+	sink = n
+}
+
+func BenchmarkDateSetIntersect(b *testing.B) {
+	dates := benchmarkDates()
+	s1 := NewDateSet(dates[:500]...)
+	s2 := NewDateSet(dates[500:]...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sink = s1.Intersect(s2).Len()
+	}
+}
+
+func BenchmarkMapIntersect(b *testing.B) {
+	dates := benchmarkDates()
+	s1 := map[Date]struct{}{}
+	s2 := map[Date]struct{}{}
+	for _, d := range dates[:500] {
+		s1[d] = struct{}{}
+	}
+	for _, d := range dates[500:] {
+		s2[d] = struct{}{}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := map[Date]struct{}{}
+		for d := range s1 {
+			if _, ok := s2[d]; ok {
+				result[d] = struct{}{}
+			}
+		}
+		sink = len(result)
+	}
+}
+
+var sink int
@@ -0,0 +1,444 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"cmp"
+	"iter"
+	"math"
+)
+
+// Interval is the constraint satisfied by the range types that IntervalIndex can hold.
+type Interval interface {
+	DateRange | TimeSpan
+}
+
+// IntervalIndex holds a collection of date ranges or time spans, each with an associated
+// value such as a reservation ID, and answers stabbing and overlap queries quickly. It is
+// a priority search tree: a balanced binary search tree (a treap) whose leaves are the
+// ranges ordered by start, in which each node also holds the range with the latest end
+// among those below it that are not held higher up.
+//
+// Insert and Delete take O(log n) expected time; deleting one of d equal ranges takes
+// O(log n + d). Overlapping and Containing take O(log n + k) expected time for k results,
+// because every node a query visits either yields a result, lies on the path to the
+// first range that starts too late, or is cut off at once. Their results are in no
+// particular order. The same range may be held many times, with the same or different
+// values.
+//
+// The zero value is an empty index ready to use. An IntervalIndex must not be copied
+// after first use, nor altered whilst a query is being iterated over.
+type IntervalIndex[R Interval, V comparable] struct {
+	root *intervalNode[R, V]
+	len  int
+	seq  uint64
+	seed uint64
+}
+
+// intervalKey is a point on the axis shared by DateRange and TimeSpan: a day number, or
+// the seconds and nanoseconds of a time.
+type intervalKey struct {
+	sec  int64
+	nsec int32
+}
+
+// noEnd is before every other key; it is the top of an empty range, which matches nothing.
+var noEnd = intervalKey{sec: math.MinInt64, nsec: math.MinInt32}
+
+func compareKeys(a, b intervalKey) int {
+	if c := cmp.Compare(a.sec, b.sec); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.nsec, b.nsec)
+}
+
+// intervalBounds gets the start (inclusive) and end (exclusive) of a range.
+func intervalBounds[R Interval](r R) (lo, hi intervalKey) {
+	switch r := any(r).(type) {
+	case DateRange:
+		return intervalKey{sec: int64(r.start)}, intervalKey{sec: int64(r.End())}
+	case TimeSpan:
+		r = r.Normalise()
+		s, e := r.Start(), r.End()
+		return intervalKey{s.Unix(), int32(s.Nanosecond())}, intervalKey{e.Unix(), int32(e.Nanosecond())}
+	}
+	panic("unreachable")
+}
+
+// intervalEntry is a range held in the index, with its value. Entries are ordered by
+// start, then end, then the order of insertion, so each is distinct.
+type intervalEntry[R Interval, V comparable] struct {
+	r      R
+	v      V
+	lo, hi intervalKey
+	top    intervalKey // hi, or noEnd if the range is empty
+	seq    uint64
+}
+
+func compareEntries[R Interval, V comparable](a, b *intervalEntry[R, V]) int {
+	if c := compareKeys(a.lo, b.lo); c != 0 {
+		return c
+	}
+	if c := compareKeys(a.hi, b.hi); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+// intervalNode is a leaf, which has no children, or an internal node, which has two.
+//
+// The split of a leaf is its own entry. The split of an internal node is the last entry
+// in its left subtree, so entries up to the split go left and later ones go right.
+//
+// The slot holds an entry from the subtree, or nil. Every entry is held in the slot of
+// a node on the path from the root to its leaf, and no slot has a later top than the
+// slot above it, an empty slot being the earliest. So a subtree whose slot is empty or
+// ends too early holds nothing that ends late enough.
+type intervalNode[R Interval, V comparable] struct {
+	split, slot *intervalEntry[R, V]
+	priority    uint64
+	left, right *intervalNode[R, V]
+}
+
+func (n *intervalNode[R, V]) isLeaf() bool {
+	return n.left == nil
+}
+
+// child gets the child whose subtree contains the entry.
+func (n *intervalNode[R, V]) child(e *intervalEntry[R, V]) *intervalNode[R, V] {
+	if compareEntries(e, n.split) <= 0 {
+		return n.left
+	}
+	return n.right
+}
+
+// push puts an entry from the subtree into the slots, moving those with earlier tops
+// down towards their leaves.
+func (n *intervalNode[R, V]) push(e *intervalEntry[R, V]) {
+	for e != nil {
+		if n.slot == nil {
+			n.slot = e
+			return
+		}
+		if compareKeys(e.top, n.slot.top) > 0 {
+			n.slot, e = e, n.slot
+		}
+		n = n.child(e)
+	}
+}
+
+// fill refills an empty slot from below, repeatedly moving up the child slot that has
+// the later top.
+func (n *intervalNode[R, V]) fill() {
+	for !n.isLeaf() {
+		c := n.left
+		if c.slot == nil || (n.right.slot != nil && compareKeys(n.right.slot.top, c.slot.top) > 0) {
+			c = n.right
+		}
+		if c.slot == nil {
+			return
+		}
+		n.slot, c.slot = c.slot, nil
+		n = c
+	}
+}
+
+// Rotations keep each split because the left subtree of each node keeps its last entry.
+// The upper node gets the slot of the former upper node, which was the latest in the
+// subtree; the other slots are then restored by fill and push.
+
+func rotateRight[R Interval, V comparable](n *intervalNode[R, V]) *intervalNode[R, V] {
+	l := n.left
+	upper, lower := n.slot, l.slot
+	n.left, l.right = l.right, n
+	l.slot, n.slot = upper, nil
+	n.fill()
+	l.push(lower)
+	return l
+}
+
+func rotateLeft[R Interval, V comparable](n *intervalNode[R, V]) *intervalNode[R, V] {
+	r := n.right
+	upper, lower := n.slot, r.slot
+	n.right, r.left = r.left, n
+	r.slot, n.slot = upper, nil
+	n.fill()
+	r.push(lower)
+	return r
+}
+
+// Len returns the number of ranges in the index.
+func (ix *IntervalIndex[R, V]) Len() int {
+	return ix.len
+}
+
+// Insert adds a range and its associated value to the index.
+func (ix *IntervalIndex[R, V]) Insert(r R, v V) {
+	lo, hi := intervalBounds(r)
+	ix.seq++
+	e := &intervalEntry[R, V]{r: r, v: v, lo: lo, hi: hi, top: hi, seq: ix.seq}
+	if compareKeys(lo, hi) >= 0 {
+		e.top = noEnd
+	}
+
+	leaf := &intervalNode[R, V]{split: e}
+	if ix.root == nil {
+		ix.root = leaf
+	} else {
+		ix.root = ix.insertLeaf(ix.root, leaf)
+	}
+	ix.root.push(e)
+	ix.len++
+}
+
+// nextPriority generates pseudo-random priorities using SplitMix64, so that the shape of
+// the tree is deterministic.
+func (ix *IntervalIndex[R, V]) nextPriority() uint64 {
+	ix.seed += 0x9e3779b97f4a7c15
+	z := ix.seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// insertLeaf puts a new leaf, whose entry is not yet in any slot, beside an existing
+// leaf under a new internal node, which then rises according to its priority.
+func (ix *IntervalIndex[R, V]) insertLeaf(n, leaf *intervalNode[R, V]) *intervalNode[R, V] {
+	if n.isLeaf() {
+		p := &intervalNode[R, V]{left: n, right: leaf, priority: ix.nextPriority()}
+		if compareEntries(leaf.split, n.split) < 0 {
+			p.left, p.right = leaf, n
+		}
+		p.split = p.left.split
+		p.fill()
+		return p
+	}
+
+	if compareEntries(leaf.split, n.split) <= 0 {
+		n.left = ix.insertLeaf(n.left, leaf)
+		if n.left.priority > n.priority {
+			n = rotateRight(n)
+		}
+	} else {
+		n.right = ix.insertLeaf(n.right, leaf)
+		if n.right.priority > n.priority {
+			n = rotateLeft(n)
+		}
+	}
+	return n
+}
+
+// Delete removes a range and its associated value from the index. If the pair was
+// inserted more than once, only one is removed. It returns false if the pair was not found.
+//
+// Ranges are matched by their start and end, so time spans are matched irrespective of
+// their location or whether they have been normalised.
+func (ix *IntervalIndex[R, V]) Delete(r R, v V) bool {
+	lo, hi := intervalBounds(r)
+	e := ix.root.find(lo, hi, v)
+	if e == nil {
+		return false
+	}
+
+	// take the entry out of the slots, then remove its leaf
+	n := ix.root
+	for n.slot != e {
+		n = n.child(e)
+	}
+	n.slot = nil
+	n.fill()
+
+	ix.unlink(e)
+	ix.len--
+	return true
+}
+
+// find gets an entry with the given start, end and value; equal ranges may be on both
+// sides of a split.
+func (n *intervalNode[R, V]) find(lo, hi intervalKey, v V) *intervalEntry[R, V] {
+	if n == nil {
+		return nil
+	}
+
+	if n.isLeaf() {
+		if e := n.split; e.lo == lo && e.hi == hi && e.v == v {
+			return e
+		}
+		return nil
+	}
+
+	c := compareKeys(lo, n.split.lo)
+	if c == 0 {
+		c = compareKeys(hi, n.split.hi)
+	}
+	if c <= 0 {
+		if e := n.left.find(lo, hi, v); e != nil {
+			return e
+		}
+	}
+	if c >= 0 {
+		return n.right.find(lo, hi, v)
+	}
+	return nil
+}
+
+// unlink removes the leaf of an entry that is in no slot. Its parent is replaced by its
+// sibling, into which the parent's slot is pushed. If the entry was the split of an
+// ancestor, the ancestor's split becomes the preceding entry, which is the parent's split.
+func (ix *IntervalIndex[R, V]) unlink(e *intervalEntry[R, V]) {
+	link := &ix.root
+	var ancestor *intervalNode[R, V]
+	for {
+		n := *link
+		if n.isLeaf() {
+			*link = nil
+			return
+		}
+
+		child, sibling := n.right, n.left
+		if compareEntries(e, n.split) <= 0 {
+			child, sibling = n.left, n.right
+		}
+
+		if child.isLeaf() {
+			sibling.push(n.slot)
+			*link = sibling
+			if ancestor != nil && child == n.right {
+				ancestor.split = n.split
+			}
+			return
+		}
+
+		if n.split == e {
+			ancestor = n
+		}
+		if child == n.left {
+			link = &n.left
+		} else {
+			link = &n.right
+		}
+	}
+}
+
+// All returns an iterator over every range and value in the index, ordered by the start,
+// then the end and then the insertion of each range.
+func (ix *IntervalIndex[R, V]) All() iter.Seq2[R, V] {
+	return func(yield func(R, V) bool) {
+		ix.root.leaves(yield)
+	}
+}
+
+func (n *intervalNode[R, V]) leaves(yield func(R, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf() {
+		return yield(n.split.r, n.split.v)
+	}
+	return n.left.leaves(yield) && n.right.leaves(yield)
+}
+
+// Overlapping returns an iterator over the ranges in the index that have at least one
+// instant in common with a window, with their values, in no particular order. Empty
+// ranges overlap nothing.
+func (ix *IntervalIndex[R, V]) Overlapping(window R) iter.Seq2[R, V] {
+	wlo, whi := intervalBounds(window)
+	return func(yield func(R, V) bool) {
+		if compareKeys(wlo, whi) >= 0 {
+			return
+		}
+		ix.root.search(overlapQuery(wlo, whi), yield)
+	}
+}
+
+func overlapQuery(wlo, whi intervalKey) *intervalQuery {
+	return &intervalQuery{
+		late:  func(top intervalKey) bool { return compareKeys(wlo, top) < 0 },
+		early: func(lo intervalKey) bool { return compareKeys(lo, whi) < 0 },
+	}
+}
+
+// Containing returns an iterator over the ranges in the index that contain the whole of
+// another range, with their values, in no particular order.
+//
+// If the other range is empty, this is a stabbing query: it finds the ranges that contain
+// its start. For example, the date ranges that contain a date d are found with
+//
+//	index.Containing(EmptyRange(d))
+func (ix *IntervalIndex[R, V]) Containing(r R) iter.Seq2[R, V] {
+	rlo, rhi := intervalBounds(r)
+	return func(yield func(R, V) bool) {
+		ix.root.search(containQuery(rlo, rhi), yield)
+	}
+}
+
+func containQuery(rlo, rhi intervalKey) *intervalQuery {
+	return &intervalQuery{
+		late:  func(top intervalKey) bool { return compareKeys(rlo, top) < 0 && compareKeys(rhi, top) <= 0 },
+		early: func(lo intervalKey) bool { return compareKeys(lo, rlo) <= 0 },
+	}
+}
+
+// intervalQuery selects the entries that end late enough and start early enough. The
+// number of nodes visited is counted so that the cost of a query can be tested.
+type intervalQuery struct {
+	late, early func(intervalKey) bool
+	visited     int
+}
+
+// search yields the entries in the subtree that the query selects. It returns false if
+// yield stopped the iteration.
+//
+// A subtree whose slot does not end late enough is skipped. Otherwise the slot's entry
+// is yielded if it starts early enough; if not, the node is on the path to the first
+// entry that starts too late. The right subtree is searched only if the split starts
+// early enough, because all the entries after it start no earlier.
+func (n *intervalNode[R, V]) search(q *intervalQuery, yield func(R, V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	q.visited++
+	e := n.slot
+	if e == nil || !q.late(e.top) {
+		return true
+	}
+	if q.early(e.lo) && !yield(e.r, e.v) {
+		return false
+	}
+	if n.isLeaf() {
+		return true
+	}
+	if !n.left.search(q, yield) {
+		return false
+	}
+	if q.early(n.split.lo) {
+		return n.right.search(q, yield)
+	}
+	return true
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// DailyCoverage counts, for each date in a window, how many of the date ranges contain it.
+// The count for date d is at index d - window.Start(). This is intended for capacity
+// planning, e.g. finding the number of rooms booked on each night.
+//
+// It uses a sweep line, so takes O(n + w) time for n ranges and a window of w days.
+func DailyCoverage(window DateRange, ranges ...DateRange) []int {
+	counts := make([]int, window.days+1)
+	for _, r := range ranges {
+		r = window.Intersect(r)
+		if r.days > 0 {
+			counts[r.start-window.start]++
+			counts[r.End()-window.start]--
+		}
+	}
+
+	for i := 1; i < len(counts); i++ {
+		counts[i] += counts[i-1]
+	}
+	return counts[:window.days]
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func collect[R Interval, V comparable](seq func(func(R, V) bool)) []V {
+	var vs []V
+	seq(func(_ R, v V) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
+
+// sorted collects the values from a query, whose results are in no particular order.
+func sorted[R Interval, V cmp.Ordered](seq func(func(R, V) bool)) []V {
+	vs := collect(seq)
+	slices.Sort(vs)
+	return vs
+}
+
+func TestIntervalIndexDateRanges(t *testing.T) {
+	var ix IntervalIndex[DateRange, string]
+	ix.Insert(BetweenDates(d0320, d0327), "a")
+	ix.Insert(BetweenDates(d0325, d0401), "b")
+	ix.Insert(BetweenDates(d0327, d0329), "c")
+	ix.Insert(BetweenDates(d0401, d0410), "d")
+	ix.Insert(EmptyRange(d0328), "e")
+	ix.Insert(BetweenDates(d0325, d0401), "f")
+
+	isEq(t, 0, ix.Len(), 6)
+	isEq(t, 0, fmt.Sprint(collect(ix.All())), "[a b f c e d]")
+
+	cases := []struct {
+		window      DateRange
+		overlapping string
+		containing  string
+	}{
+		{window: EmptyRange(d0320), overlapping: "[]", containing: "[a]"},
+		{window: EmptyRange(d0327), overlapping: "[]", containing: "[b c f]"},
+		{window: EmptyRange(d0328), overlapping: "[]", containing: "[b c f]"},
+		{window: EmptyRange(d0401), overlapping: "[]", containing: "[d]"},
+		{window: EmptyRange(d0410), overlapping: "[]", containing: "[]"},
+		{window: OneDayRange(d0326), overlapping: "[a b f]", containing: "[a b f]"},
+		{window: BetweenDates(d0326, d0328), overlapping: "[a b c f]", containing: "[b f]"},
+		{window: BetweenDates(d0321, d0403), overlapping: "[a b c d f]", containing: "[]"},
+		{window: BetweenDates(d0407, d0501), overlapping: "[d]", containing: "[]"},
+		{window: BetweenDates(d0410, d0501), overlapping: "[]", containing: "[]"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(sorted(ix.Overlapping(c.window))), c.overlapping, c.window)
+		isEq(t, i, fmt.Sprint(sorted(ix.Containing(c.window))), c.containing, c.window)
+	}
+
+	isEq(t, 0, ix.Delete(BetweenDates(d0325, d0401), "f"), true)
+	isEq(t, 0, ix.Delete(BetweenDates(d0325, d0401), "f"), false)
+	isEq(t, 0, ix.Delete(BetweenDates(d0325, d0402), "b"), false)
+	isEq(t, 0, ix.Len(), 5)
+	isEq(t, 0, fmt.Sprint(sorted(ix.Containing(EmptyRange(d0327)))), "[b c]")
+
+	// stopping early
+	n := 0
+	for range ix.Overlapping(BetweenDates(d0320, d0501)) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	isEq(t, 0, n, 2)
+}
+
+func TestIntervalIndexTimeSpans(t *testing.T) {
+	h := func(n int) time.Time { return t0327.Add(time.Duration(n) * time.Hour) }
+
+	var ix IntervalIndex[TimeSpan, int]
+	ix.Insert(BetweenTimes(h(9), h(12)), 1)
+	ix.Insert(TimeSpanOf(h(14), -3*time.Hour), 2) // 11:00 to 14:00
+	ix.Insert(BetweenTimes(h(13), h(17)).In(london), 3)
+
+	isEq(t, 0, fmt.Sprint(sorted(ix.Containing(ZeroTimeSpan(h(11))))), "[1 2]")
+	isEq(t, 0, fmt.Sprint(sorted(ix.Containing(ZeroTimeSpan(h(12))))), "[2]")
+	isEq(t, 0, fmt.Sprint(sorted(ix.Containing(ZeroTimeSpan(h(13)).In(london)))), "[2 3]")
+	isEq(t, 0, fmt.Sprint(sorted(ix.Overlapping(BetweenTimes(h(12), h(13))))), "[2]")
+	isEq(t, 0, fmt.Sprint(sorted(ix.Overlapping(BetweenTimes(h(0), h(24))))), "[1 2 3]")
+
+	isEq(t, 0, ix.Delete(BetweenTimes(h(11), h(14)), 2), true)
+	isEq(t, 0, ix.Delete(BetweenTimes(h(13), h(17)), 3), true)
+	isEq(t, 0, fmt.Sprint(collect(ix.All())), "[1]")
+}
+
+func TestIntervalIndexAgainstBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomRange := func() DateRange {
+		return DateRange{start: d0320 + Date(rnd.Intn(100)), days: PeriodOfDays(rnd.Intn(20))}
+	}
+
+	var ix IntervalIndex[DateRange, int]
+	ranges := map[int]DateRange{}
+	for i := 0; i < 1000; i++ {
+		ranges[i] = randomRange()
+		ix.Insert(ranges[i], i)
+	}
+	for i := 0; i < 1000; i += 3 {
+		isEq(t, i, ix.Delete(ranges[i], i), true)
+		delete(ranges, i)
+	}
+	isEq(t, 0, ix.Len(), len(ranges))
+	leaves, held := checkIntervalNodes(t, ix.root, nil)
+	isEq(t, 0, len(held), len(leaves))
+	isEq(t, 0, len(leaves), len(ranges))
+
+	for i := 0; i < 200; i++ {
+		w := randomRange()
+
+		var overlapping, containing []int
+		for v, r := range ranges {
+			if r.Overlaps(w) {
+				overlapping = append(overlapping, v)
+			}
+			if r.days > 0 && r.start <= w.start && w.End() <= r.End() && w.start < r.End() {
+				containing = append(containing, v)
+			}
+		}
+
+		gotOverlapping := collect(ix.Overlapping(w))
+		gotContaining := collect(ix.Containing(w))
+		slices.Sort(overlapping)
+		slices.Sort(containing)
+		slices.Sort(gotOverlapping)
+		slices.Sort(gotContaining)
+		isEq(t, i, fmt.Sprint(gotOverlapping), fmt.Sprint(overlapping), w)
+		isEq(t, i, fmt.Sprint(gotContaining), fmt.Sprint(containing), w)
+	}
+
+	for v, r := range ranges {
+		isEq(t, v, ix.Delete(r, v), true)
+	}
+	isEq(t, 0, ix.Len(), 0)
+	isEq(t, 0, len(collect(ix.All())), 0)
+}
+
+func TestIntervalIndexQueryCost(t *testing.T) {
+	// one long range in every 64, amongst short ones; a query must skip the short ranges
+	// without visiting them, so that its cost depends on the long ranges it finds
+	const n, every = 1 << 14, 64
+	var ix IntervalIndex[DateRange, int]
+	for i := 0; i < n; i++ {
+		days := PeriodOfDays(1)
+		if i%every == 0 {
+			days = 2 * n
+		}
+		ix.Insert(DateRange{start: d0320 + Date(i), days: days}, i)
+	}
+
+	height := treeHeight(ix.root)
+	end := d0320 + n
+
+	cases := []struct {
+		q *intervalQuery
+		k int
+	}{
+		{q: containQuery(intervalBounds(EmptyRange(end + 10))), k: n / every},
+		{q: containQuery(intervalBounds(EmptyRange(d0320 + n/2))), k: n/every/2 + 1},
+		{q: containQuery(intervalBounds(BetweenDates(end, end+10))), k: n / every},
+		{q: overlapQuery(intervalBounds(BetweenDates(end+5, end+10))), k: n / every},
+		{q: overlapQuery(intervalBounds(BetweenDates(d0320+100, d0320+110))), k: 10 + 100/every + 1},
+		{q: overlapQuery(intervalBounds(BetweenDates(end+3*n, end+4*n))), k: 0},
+	}
+
+	for i, c := range cases {
+		k := 0
+		ix.root.search(c.q, func(DateRange, int) bool {
+			k++
+			return true
+		})
+		isEq(t, i, k, c.k)
+
+		// every node visited yields a result or is on the path to the boundary of the
+		// start, or else is a child of one of these
+		if bound := 3 * (k + 2*height + 2); c.q.visited > bound {
+			t.Errorf("%d: visited %d nodes for %d results, more than %d", i, c.q.visited, k, bound)
+		}
+	}
+}
+
+// checkIntervalNodes checks that each entry is held by one slot on the path to its leaf,
+// that no slot ends later than the one above it and that each split is the last entry
+// of the left subtree. It returns the entries of the leaves and those held in slots.
+func checkIntervalNodes[R Interval, V comparable](t *testing.T, n *intervalNode[R, V], above *intervalEntry[R, V]) (leaves, held []*intervalEntry[R, V]) {
+	t.Helper()
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.slot != nil {
+		held = append(held, n.slot)
+		if above != nil && compareKeys(n.slot.top, above.top) > 0 {
+			t.Errorf("slot %v ends after %v", n.slot.r, above.r)
+		}
+	} else if n.left != nil && (n.left.slot != nil || n.right.slot != nil) {
+		t.Errorf("empty slot above %v", n.split.r)
+	}
+
+	if n.isLeaf() {
+		leaves = append(leaves, n.split)
+	} else {
+		l1, h1 := checkIntervalNodes(t, n.left, n.slot)
+		l2, h2 := checkIntervalNodes(t, n.right, n.slot)
+		if l1[len(l1)-1] != n.split {
+			t.Errorf("split %v is not the last of its left subtree", n.split.r)
+		}
+		leaves = append(l1, l2...)
+		held = append(append(held, h1...), h2...)
+	}
+
+	// the entries held in this subtree have leaves within it
+	for _, e := range held {
+		if !slices.Contains(leaves, e) {
+			t.Errorf("entry %v is held outside its subtree", e.r)
+		}
+	}
+	return leaves, held
+}
+
+func treeHeight[R Interval, V comparable](n *intervalNode[R, V]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + max(treeHeight(n.left), treeHeight(n.right))
+}
+
+func TestDailyCoverage(t *testing.T) {
+	window := BetweenDates(d0325, d0401)
+	counts := DailyCoverage(window,
+		BetweenDates(d0320, d0327),
+		BetweenDates(d0326, d0329),
+		BetweenDates(d0328, d0410),
+		EmptyRange(d0327),
+		BetweenDates(d0401, d0410),
+	)
+	isEq(t, 0, fmt.Sprint(counts), "[1 2 1 2 1 1 1]")
+	isEq(t, 0, len(DailyCoverage(EmptyRange(d0325), BetweenDates(d0320, d0327))), 0)
+}
+
+func BenchmarkIntervalIndexContaining(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	var ix IntervalIndex[DateRange, int]
+	for i := 0; i < 100000; i++ {
+		ix.Insert(DateRange{start: d0320 + Date(rnd.Intn(3650)), days: PeriodOfDays(1 + rnd.Intn(14))}, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range ix.Containing(EmptyRange(d0320 + Date(i%3650))) {
+		}
+	}
+}
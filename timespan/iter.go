@@ -0,0 +1,183 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"iter"
+	"time"
+
+	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/period"
+)
+
+// Dates returns an iterator over every date in the range, in ascending order.
+//
+//	for d := range dateRange.Dates() {
+//		...
+//	}
+func (dateRange DateRange) Dates() iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		for d := dateRange.start; d < dateRange.End(); d++ {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every date in the range, in descending order,
+// i.e. from the last date back to the start date.
+func (dateRange DateRange) Backward() iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		for d := dateRange.End() - 1; d >= dateRange.start; d-- {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Weeks returns an iterator over the dates in the range that fall on a given weekday,
+// i.e. the first day of each week that starts within the range.
+func (dateRange DateRange) Weeks(startDay time.Weekday) iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		offset := (startDay - dateRange.start.Weekday() + 7) % 7
+		for d := dateRange.start + date.Date(offset); d < dateRange.End(); d += 7 {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Months returns an iterator over the dates in the range that are the first day of
+// a month, i.e. the first day of each month that starts within the range.
+func (dateRange DateRange) Months() iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		y, m, d := dateRange.start.Date()
+		if d != 1 {
+			m++
+		}
+		for d := date.New(y, m, 1); d < dateRange.End(); d = d.AddDate(0, 1, 0) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Step returns an iterator over the dates in the range, starting at the start date and
+// moving by a given period each time. Each date is calculated from the start date rather
+// than from the previous date, so errors do not accumulate. As with Date.AddDate, dates
+// that overflow the end of a month are normalised: stepping by P1M from 31st January 2023
+// gives 3rd March and then 31st March.
+//
+// If the period is negative, the iteration is backwards, starting from the last date.
+// Any time component of the period is ignored; if the period has no years, months,
+// weeks or days, nothing is yielded.
+func (dateRange DateRange) Step(p period.Period) iter.Seq[date.Date] {
+	return func(yield func(date.Date) bool) {
+		if dateRange.days == 0 {
+			return
+		}
+
+		years, months, days := p.Years(), p.Months(), p.DaysIncWeeks()
+		if years == 0 && months == 0 && days == 0 {
+			return
+		}
+
+		from := dateRange.start
+		if p.IsNegative() {
+			from = dateRange.Last()
+		}
+
+		for n := 0; ; n++ {
+			d := addDate(from, n*years, n*months, n*days)
+			if !dateRange.Contains(d) || !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// SubRanges returns an iterator over successive sub-ranges, each of the length given
+// by the period, covering the whole range. The last sub-range is shortened if necessary
+// so that it ends with the range. As with Step, each boundary is calculated from the
+// start date and any time component of the period is ignored. If the period is not
+// positive, nothing is yielded.
+func (dateRange DateRange) SubRanges(p period.Period) iter.Seq[DateRange] {
+	return func(yield func(DateRange) bool) {
+		years, months, days := p.Years(), p.Months(), p.DaysIncWeeks()
+		if p.IsNegative() || (years == 0 && months == 0 && days == 0) {
+			return
+		}
+
+		end := dateRange.End()
+		from := dateRange.start
+		for n := 1; from < end; n++ {
+			to := min(addDate(dateRange.start, n*years, n*months, n*days), end)
+			if !yield(BetweenDates(from, to)) {
+				return
+			}
+			from = to
+		}
+	}
+}
+
+// addDate avoids the conversion to time.Time when only days are being added.
+func addDate(d date.Date, years, months, days int) date.Date {
+	if years == 0 && months == 0 {
+		return d + date.Date(days)
+	}
+	return d.AddDate(years, months, days)
+}
+
+// DailySpansIn returns an iterator over the time spans given by a time-of-day window on
+// each date in the range, in the specified location. For example, opening hours of
+// "09:00-17:30" give one span from 9am to 5:30pm on each date.
+//
+// Each span starts on its date. If the window wraps midnight, such as "22:00-06:00", each
+// span ends on the following date, so the last span ends after the date range.
+//
+// The spans are DST-correct: on the dates when the clocks change, a span may be an hour
+// shorter or longer than the window's nominal Duration. Clock times that do not exist,
+// because the clocks went forward, are resolved as per date.Date.Time. Empty windows give
+// no spans.
+func (dateRange DateRange) DailySpansIn(window clock.Range, loc *time.Location) iter.Seq[TimeSpan] {
+	return func(yield func(TimeSpan) bool) {
+		if window.IsEmpty() {
+			return
+		}
+
+		end := window.End()
+		if window.WrapsMidnight() {
+			end += clock.Day
+		}
+
+		for d := range dateRange.Dates() {
+			if !yield(BetweenTimes(d.Time(window.Start(), loc), d.Time(end, loc))) {
+				return
+			}
+		}
+	}
+}
+
+// ConvertDailyWindow returns an iterator over a time-of-day window on each date in the
+// range, as per DailySpansIn in the location from, converted to the dates and wall-clock
+// times of its start and end in the location to. For example, "09:00-17:00" in London on
+// 2024-03-31 is from 2024-03-31T19:00 to 2024-04-01T03:00 in Sydney.
+//
+// Either side can observe daylight saving, so the converted window can differ from one
+// date to the next, and it may start and end on different dates.
+func (dateRange DateRange) ConvertDailyWindow(window clock.Range, from, to *time.Location) iter.Seq2[date.LocalDateTime, date.LocalDateTime] {
+	return func(yield func(date.LocalDateTime, date.LocalDateTime) bool) {
+		for ts := range dateRange.DailySpansIn(window, from) {
+			if !yield(date.LocalDateTimeAt(ts.Start().In(to)), date.LocalDateTimeAt(ts.End().In(to))) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/clock"
+	"github.com/rickb777/period"
+)
+
+func TestDateRangeDates(t *testing.T) {
+	dr := BetweenDates(d0327, d0401)
+	isEq(t, 0, fmt.Sprint(slices.Collect(dr.Dates())), "[2015-03-27 2015-03-28 2015-03-29 2015-03-30 2015-03-31]")
+	isEq(t, 0, fmt.Sprint(slices.Collect(dr.Backward())), "[2015-03-31 2015-03-30 2015-03-29 2015-03-28 2015-03-27]")
+	isEq(t, 0, len(slices.Collect(EmptyRange(d0327).Dates())), 0)
+	isEq(t, 0, len(slices.Collect(EmptyRange(d0327).Backward())), 0)
+
+	// early exit
+	n := 0
+	for d := range dr.Dates() {
+		if d == d0329 {
+			break
+		}
+		n++
+	}
+	isEq(t, 0, n, 2)
+}
+
+func TestDateRangeWeeks(t *testing.T) {
+	dr := BetweenDates(d0320, d0410) // Friday to Friday
+
+	cases := []struct {
+		startDay time.Weekday
+		exp      string
+	}{
+		{startDay: time.Monday, exp: "[2015-03-23 2015-03-30 2015-04-06]"},
+		{startDay: time.Friday, exp: "[2015-03-20 2015-03-27 2015-04-03]"},
+		{startDay: time.Thursday, exp: "[2015-03-26 2015-04-02 2015-04-09]"},
+		{startDay: time.Sunday, exp: "[2015-03-22 2015-03-29 2015-04-05]"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(slices.Collect(dr.Weeks(c.startDay))), c.exp)
+	}
+}
+
+func TestDateRangeMonths(t *testing.T) {
+	cases := []struct {
+		dr  DateRange
+		exp string
+	}{
+		{dr: BetweenDates(d0320, d0501), exp: "[2015-04-01]"},
+		{dr: BetweenDates(d0320, New(2015, time.May, 2)), exp: "[2015-04-01 2015-05-01]"},
+		{dr: BetweenDates(New(2015, time.March, 1), d0401), exp: "[2015-03-01]"},
+		{dr: NewYearOf(2016), exp: "[2016-01-01 2016-02-01 2016-03-01 2016-04-01 2016-05-01 2016-06-01 2016-07-01 2016-08-01 2016-09-01 2016-10-01 2016-11-01 2016-12-01]"},
+		{dr: BetweenDates(New(2015, time.December, 5), New(2016, time.February, 2)), exp: "[2016-01-01 2016-02-01]"},
+		{dr: BetweenDates(d0320, d0331), exp: "[]"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(slices.Collect(c.dr.Months())), c.exp)
+	}
+}
+
+func TestDateRangeStep(t *testing.T) {
+	cases := []struct {
+		dr  DateRange
+		p   string
+		exp string
+	}{
+		{dr: BetweenDates(d0320, d0401), p: "P3D", exp: "[2015-03-20 2015-03-23 2015-03-26 2015-03-29]"},
+		{dr: BetweenDates(d0320, d0401), p: "P1W", exp: "[2015-03-20 2015-03-27]"},
+		{dr: BetweenDates(d0320, d0401), p: "-P3D", exp: "[2015-03-31 2015-03-28 2015-03-25 2015-03-22]"},
+		{dr: BetweenDates(New(2023, time.January, 31), New(2023, time.June, 1)), p: "P1M", exp: "[2023-01-31 2023-03-03 2023-03-31 2023-05-01 2023-05-31]"},
+		{dr: BetweenDates(New(2020, time.February, 29), New(2025, time.January, 1)), p: "P1Y", exp: "[2020-02-29 2021-03-01 2022-03-01 2023-03-01 2024-02-29]"},
+		{dr: BetweenDates(d0320, d0401), p: "PT24H", exp: "[]"},
+		{dr: EmptyRange(d0320), p: "P1D", exp: "[]"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(slices.Collect(c.dr.Step(period.MustParse(c.p)))), c.exp, c.p)
+	}
+}
+
+func TestDateRangeSubRanges(t *testing.T) {
+	cases := []struct {
+		dr  DateRange
+		p   string
+		exp []DateRange
+	}{
+		{dr: BetweenDates(d0320, d0401), p: "P5D", exp: []DateRange{BetweenDates(d0320, d0325), BetweenDates(d0325, d0330), BetweenDates(d0330, d0401)}},
+		{dr: BetweenDates(d0320, d0330), p: "P5D", exp: []DateRange{BetweenDates(d0320, d0325), BetweenDates(d0325, d0330)}},
+		{dr: BetweenDates(d0320, d0501), p: "P1M", exp: []DateRange{BetweenDates(d0320, New(2015, time.April, 20)), BetweenDates(New(2015, time.April, 20), d0501)}},
+		{dr: BetweenDates(d0320, d0325), p: "P1M", exp: []DateRange{BetweenDates(d0320, d0325)}},
+		{dr: BetweenDates(d0320, d0325), p: "-P1D"},
+		{dr: BetweenDates(d0320, d0325), p: "P0D"},
+		{dr: EmptyRange(d0320), p: "P1D"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, fmt.Sprint(slices.Collect(c.dr.SubRanges(period.MustParse(c.p)))), fmt.Sprint(c.exp), c.p)
+	}
+
+	// early exit
+	next, stop := iter.Pull(BetweenDates(d0320, d0401).SubRanges(period.MustParse("P1D")))
+	defer stop()
+	r, ok := next()
+	isEq(t, 0, ok, true)
+	isEq(t, 0, r, OneDayRange(d0320))
+}
+
+func BenchmarkDateRangeDates(b *testing.B) {
+	dr := NewYearOf(2024)
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for range dr.Dates() {
+			n++
+		}
+	}
+}
+
+func TestDailySpansIn(t *testing.T) {
+	cases := []struct {
+		dr     DateRange
+		window clock.Range
+		loc    *time.Location
+		exp    []string
+	}{
+		{
+			dr:     BetweenDates(d0320, d0320+3),
+			window: clock.MustParseRange("09:00-17:30"),
+			loc:    time.UTC,
+			exp:    []string{"2015-03-20T09:00:00Z/8h30m0s", "2015-03-21T09:00:00Z/8h30m0s", "2015-03-22T09:00:00Z/8h30m0s"},
+		},
+		{
+			// London clocks go forward at 1am on 29th March 2015
+			dr:     BetweenDates(d0328, d0330),
+			window: clock.MustParseRange("22:00-06:00"),
+			loc:    london,
+			exp:    []string{"2015-03-28T22:00:00Z/7h0m0s", "2015-03-29T22:00:00+01:00/8h0m0s"},
+		},
+		{
+			// London clocks go back at 2am on 25th October 2015
+			dr:     BetweenDates(d1025-1, d1026),
+			window: clock.AllDay,
+			loc:    london,
+			exp:    []string{"2015-10-24T00:00:00+01:00/24h0m0s", "2015-10-25T00:00:00+01:00/25h0m0s"},
+		},
+		{
+			// 01:30 does not exist in London on 29th March 2015
+			dr:     OneDayRange(d0329),
+			window: clock.MustParseRange("01:30-03:00"),
+			loc:    london,
+			exp:    []string{"2015-03-29T02:30:00+01:00/30m0s"},
+		},
+		{
+			dr:     BetweenDates(d0320, d0320+3),
+			window: clock.Range{},
+			loc:    time.UTC,
+		},
+		{
+			dr:     EmptyRange(d0320),
+			window: clock.AllDay,
+			loc:    time.UTC,
+		},
+	}
+
+	for i, c := range cases {
+		var got []string
+		for ts := range c.dr.DailySpansIn(c.window, c.loc) {
+			got = append(got, ts.Start().Format(time.RFC3339)+"/"+ts.Duration().String())
+		}
+		isEq(t, i, strings.Join(got, " "), strings.Join(c.exp, " "))
+	}
+}
+
+func TestConvertDailyWindow(t *testing.T) {
+	sydney, _ := time.LoadLocation("Australia/Sydney")
+
+	// London clocks go forward at 1am on 29th March 2015; Sydney is on summer time (+11:00)
+	var got []string
+	for start, end := range BetweenDates(d0327, d0330).ConvertDailyWindow(clock.MustParseRange("09:00-17:00"), london, sydney) {
+		got = append(got, start.String()+"/"+end.String())
+	}
+	isEq(t, 0, strings.Join(got, " "), "2015-03-27T20:00:00/2015-03-28T04:00:00 2015-03-28T20:00:00/2015-03-29T04:00:00 2015-03-29T19:00:00/2015-03-30T03:00:00")
+
+	got = nil
+	for start, end := range OneDayRange(d0329).ConvertDailyWindow(clock.AllDay, london, time.UTC) {
+		got = append(got, start.String()+"/"+end.String())
+	}
+	isEq(t, 1, strings.Join(got, " "), "2015-03-29T00:00:00/2015-03-29T23:00:00")
+}
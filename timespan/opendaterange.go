@@ -0,0 +1,262 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/rickb777/date/v2"
+)
+
+// OpenDateRange is a range of dates that may have no start, no end, or neither, for
+// example "from 2024-03-01 onwards". Where a bound is present, it behaves like DateRange:
+// the start is included and the end is excluded.
+//
+// The zero value is unbounded at both ends and so contains every date.
+type OpenDateRange struct {
+	start, end       date.Date
+	hasStart, hasEnd bool
+}
+
+// OpenDateRangeOf converts a date range into an open date range with both bounds present.
+func OpenDateRangeOf(dateRange DateRange) OpenDateRange {
+	return OpenDateRange{start: dateRange.start, end: dateRange.End(), hasStart: true, hasEnd: true}
+}
+
+// StartingFrom constructs a range that starts on a given date and has no end.
+func StartingFrom(start date.Date) OpenDateRange {
+	return OpenDateRange{start: start, hasStart: true}
+}
+
+// EndingBefore constructs a range that has no start and ends before a given date,
+// which is excluded.
+func EndingBefore(end date.Date) OpenDateRange {
+	return OpenDateRange{end: end, hasEnd: true}
+}
+
+// Start returns the start date, which is included in the range. The flag is false if
+// the range has no start.
+func (r OpenDateRange) Start() (date.Date, bool) {
+	return r.start, r.hasStart
+}
+
+// End returns the end date, which is excluded from the range. The flag is false if
+// the range has no end.
+func (r OpenDateRange) End() (date.Date, bool) {
+	return r.end, r.hasEnd
+}
+
+// IsBounded returns true if the range has both a start and an end.
+func (r OpenDateRange) IsBounded() bool {
+	return r.hasStart && r.hasEnd
+}
+
+// IsEmpty returns true if the range contains no dates.
+func (r OpenDateRange) IsEmpty() bool {
+	return r.IsBounded() && r.end <= r.start
+}
+
+// DateRange converts the range to a DateRange. The flag is false if the range is not
+// bounded at both ends, in which case the zero DateRange is returned.
+func (r OpenDateRange) DateRange() (DateRange, bool) {
+	if !r.IsBounded() {
+		return DateRange{}, false
+	}
+	return BetweenDates(r.start, max(r.start, r.end)), true
+}
+
+// Contains tests whether the range contains a specified date.
+func (r OpenDateRange) Contains(d date.Date) bool {
+	return (!r.hasStart || r.start <= d) && (!r.hasEnd || d < r.end)
+}
+
+// Overlaps tests whether the two ranges have at least one date in common.
+func (r OpenDateRange) Overlaps(other OpenDateRange) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the dates that are in both ranges. If the ranges do not overlap,
+// the result is empty.
+func (r OpenDateRange) Intersect(other OpenDateRange) OpenDateRange {
+	result := r
+	if other.hasStart && (!result.hasStart || other.start > result.start) {
+		result.start, result.hasStart = other.start, true
+	}
+	if other.hasEnd && (!result.hasEnd || other.end < result.end) {
+		result.end, result.hasEnd = other.end, true
+	}
+	if result.IsEmpty() {
+		result.end = result.start
+	}
+	return result
+}
+
+// Clip returns the dates in a date range that are also in this range. If there are
+// none, the result is an empty range.
+func (r OpenDateRange) Clip(dateRange DateRange) DateRange {
+	dr, _ := r.Intersect(OpenDateRangeOf(dateRange)).DateRange()
+	return dr
+}
+
+// String formats the range as per FormatISO with InclusiveEnd, e.g. "2024-03-01/..".
+func (r OpenDateRange) String() string {
+	return r.FormatISO(InclusiveEnd)
+}
+
+// FormatISO formats the range as an ISO 8601 interval using the specified form for the
+// end date. As per ISO 8601-2, a missing bound is written as "..", e.g. "2024-03-01/..".
+func (r OpenDateRange) FormatISO(end EndForm) string {
+	if dr, ok := r.DateRange(); ok {
+		return dr.FormatISO(end)
+	}
+
+	first, second := "..", ".."
+	if r.hasStart {
+		first = r.start.String()
+	}
+	if r.hasEnd && end == InclusiveEnd {
+		second = (r.end - 1).String()
+	} else if r.hasEnd {
+		second = r.end.String()
+	}
+	return first + "/" + second
+}
+
+// MarshalText formats the range as an ISO 8601 interval with an inclusive end date, e.g.
+// "2024-03-01/..". Use FormatISO for the exclusive form.
+// This implements the encoding.TextMarshaler interface.
+func (r OpenDateRange) MarshalText() ([]byte, error) {
+	return []byte(r.FormatISO(InclusiveEnd)), nil
+}
+
+// UnmarshalText parses an ISO 8601 interval using ParseOpenDateRange with InclusiveEnd.
+// This implements the encoding.TextUnmarshaler interface.
+func (r *OpenDateRange) UnmarshalText(text []byte) (err error) {
+	*r, err = ParseOpenDateRange(string(text), InclusiveEnd)
+	return err
+}
+
+// ParseOpenDateRange parses an ISO 8601 interval of dates in which either bound may be
+// missing. A missing bound is written as "..", or may be left blank, e.g. "2024-03-01/..",
+// "../2024-03-31" or "2024-03-01/". Otherwise, the forms accepted are those of
+// ParseDateRange, and the end date is interpreted using the specified form.
+func ParseOpenDateRange(text string, end EndForm) (OpenDateRange, error) {
+	first, second, found := strings.Cut(text, "/")
+	if !found {
+		return OpenDateRange{}, fmt.Errorf("timespan.ParseOpenDateRange: cannot parse %q because there is no separator '/'", text)
+	}
+
+	openStart := first == "" || first == ".."
+	openEnd := second == "" || second == ".."
+
+	if !openStart && !openEnd {
+		dr, err := parseDateRange(first, second, end)
+		if err != nil {
+			return OpenDateRange{}, fmt.Errorf("timespan.ParseOpenDateRange: cannot parse %q: %w", text, err)
+		}
+		return OpenDateRangeOf(dr), nil
+	}
+
+	var r OpenDateRange
+	if !openStart {
+		d, err := date.ParseISO(first)
+		if err != nil {
+			return OpenDateRange{}, fmt.Errorf("timespan.ParseOpenDateRange: cannot parse %q: %w", text, err)
+		}
+		r.start, r.hasStart = d, true
+	}
+
+	if !openEnd {
+		d, err := date.ParseISO(second)
+		if err != nil {
+			return OpenDateRange{}, fmt.Errorf("timespan.ParseOpenDateRange: cannot parse %q: %w", text, err)
+		}
+		r.end, r.hasEnd = exclusiveEnd(d, end), true
+	}
+
+	return r, nil
+}
+
+// Scan parses a range literal as per DateRange.Scan, except that unbounded (infinite)
+// lower and upper bounds give a range with no start or no end respectively.
+//
+// This implements sql.Scanner https://golang.org/pkg/database/sql/#Scanner
+func (r *OpenDateRange) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	s, err := rangeString(value)
+	if err != nil {
+		return err
+	}
+
+	rl, err := parseRangeLiteral(s)
+	if err != nil {
+		return err
+	}
+
+	var result OpenDateRange
+	if rl.empty {
+		result.hasStart, result.hasEnd = true, true
+		*r = result
+		return nil
+	}
+
+	if !rl.lowerInfinite {
+		result.start, err = date.ParseISO(rl.lower)
+		if err != nil {
+			return err
+		}
+		if !rl.lowerInclusive {
+			result.start++
+		}
+		result.hasStart = true
+	}
+
+	if !rl.upperInfinite {
+		result.end, err = date.ParseISO(rl.upper)
+		if err != nil {
+			return err
+		}
+		if rl.upperInclusive {
+			result.end++
+		}
+		result.hasEnd = true
+	}
+
+	if result.IsBounded() && result.end < result.start {
+		return fmt.Errorf("timespan: range %q has its upper bound before its lower bound", s)
+	}
+
+	*r = result
+	return nil
+}
+
+// Value converts the range to a range literal such as "[2024-03-01,)", in which a missing
+// bound is unbounded (infinite). Empty ranges are converted to "empty".
+//
+// This implements driver.Valuer https://golang.org/pkg/database/sql/driver/#Valuer
+func (r OpenDateRange) Value() (driver.Value, error) {
+	if r.IsEmpty() {
+		return "empty", nil
+	}
+
+	buf := &strings.Builder{}
+	if r.hasStart {
+		buf.WriteByte('[')
+		buf.WriteString(r.start.String())
+	} else {
+		buf.WriteByte('(')
+	}
+	buf.WriteByte(',')
+	if r.hasEnd {
+		buf.WriteString(r.end.String())
+	}
+	buf.WriteByte(')')
+	return buf.String(), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenDateRangeContains(t *testing.T) {
+	cases := []struct {
+		r                   OpenDateRange
+		c0320, c0325, c0401 bool
+		bounded, empty      bool
+	}{
+		{r: OpenDateRange{}, c0320: true, c0325: true, c0401: true},
+		{r: StartingFrom(d0325), c0325: true, c0401: true},
+		{r: EndingBefore(d0325), c0320: true},
+		{r: OpenDateRangeOf(BetweenDates(d0320, d0401)), c0320: true, c0325: true, bounded: true},
+		{r: OpenDateRangeOf(EmptyRange(d0325)), bounded: true, empty: true},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.r.Contains(d0320), c.c0320, c.r)
+		isEq(t, i, c.r.Contains(d0325), c.c0325, c.r)
+		isEq(t, i, c.r.Contains(d0401), c.c0401, c.r)
+		isEq(t, i, c.r.IsBounded(), c.bounded, c.r)
+		isEq(t, i, c.r.IsEmpty(), c.empty, c.r)
+	}
+
+	s, ok := StartingFrom(d0325).Start()
+	isEq(t, 0, s, d0325)
+	isEq(t, 0, ok, true)
+	_, ok = StartingFrom(d0325).End()
+	isEq(t, 0, ok, false)
+	_, ok = StartingFrom(d0325).DateRange()
+	isEq(t, 0, ok, false)
+	dr, ok := OpenDateRangeOf(BetweenDates(d0320, d0401)).DateRange()
+	isEq(t, 0, ok, true)
+	isEq(t, 0, dr, BetweenDates(d0320, d0401))
+}
+
+func TestOpenDateRangeIntersect(t *testing.T) {
+	cases := []struct {
+		a, b, exp OpenDateRange
+	}{
+		{a: OpenDateRange{}, b: OpenDateRange{}, exp: OpenDateRange{}},
+		{a: OpenDateRange{}, b: StartingFrom(d0325), exp: StartingFrom(d0325)},
+		{a: StartingFrom(d0320), b: StartingFrom(d0325), exp: StartingFrom(d0325)},
+		{a: EndingBefore(d0320), b: EndingBefore(d0325), exp: EndingBefore(d0320)},
+		{a: StartingFrom(d0320), b: EndingBefore(d0325), exp: OpenDateRangeOf(BetweenDates(d0320, d0325))},
+		{a: StartingFrom(d0325), b: EndingBefore(d0320), exp: OpenDateRangeOf(EmptyRange(d0325))},
+		{a: StartingFrom(d0325), b: OpenDateRangeOf(BetweenDates(d0320, d0401)), exp: OpenDateRangeOf(BetweenDates(d0325, d0401))},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Intersect(c.b), c.exp, c.a, c.b)
+		isEq(t, i, c.b.Intersect(c.a), c.exp, c.a, c.b)
+		isEq(t, i, c.a.Overlaps(c.b), !c.exp.IsEmpty(), c.a, c.b)
+	}
+
+	isEq(t, 0, StartingFrom(d0325).Clip(BetweenDates(d0320, d0401)), BetweenDates(d0325, d0401))
+	isEq(t, 0, StartingFrom(d0401).Clip(BetweenDates(d0320, d0325)).IsEmpty(), true)
+}
+
+func TestOpenDateRangeText(t *testing.T) {
+	cases := []struct {
+		r                    OpenDateRange
+		inclusive, exclusive string
+	}{
+		{r: StartingFrom(d0325), inclusive: "2015-03-25/..", exclusive: "2015-03-25/.."},
+		{r: EndingBefore(d0401), inclusive: "../2015-03-31", exclusive: "../2015-04-01"},
+		{r: OpenDateRange{}, inclusive: "../..", exclusive: "../.."},
+		{r: OpenDateRangeOf(BetweenDates(d0320, d0401)), inclusive: "2015-03-20/2015-03-31", exclusive: "2015-03-20/2015-04-01"},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.r.FormatISO(InclusiveEnd), c.inclusive)
+		isEq(t, i, c.r.FormatISO(ExclusiveEnd), c.exclusive)
+		isEq(t, i, c.r.String(), c.inclusive)
+
+		r, err := ParseOpenDateRange(c.inclusive, InclusiveEnd)
+		isEq(t, i, err, nil)
+		isEq(t, i, r, c.r)
+		r, err = ParseOpenDateRange(c.exclusive, ExclusiveEnd)
+		isEq(t, i, err, nil)
+		isEq(t, i, r, c.r)
+	}
+
+	r, err := ParseOpenDateRange("2015-03-25/", InclusiveEnd)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, r, StartingFrom(d0325))
+
+	for i, c := range []string{"2015-03-25", "x/..", "../x", "2015-03-25/2015-03-20", "P1D/.."} {
+		_, err := ParseOpenDateRange(c, InclusiveEnd)
+		isEq(t, i, err != nil, true, c)
+	}
+}
+
+func TestOpenDateRangeJSON(t *testing.T) {
+	type contract struct {
+		Term OpenDateRange `json:"term"`
+	}
+
+	b, err := json.Marshal(contract{Term: StartingFrom(d0325)})
+	isEq(t, 0, err, nil)
+	isEq(t, 0, string(b), `{"term":"2015-03-25/.."}`)
+
+	var v contract
+	err = json.Unmarshal([]byte(`{"term":"../2015-03-31"}`), &v)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, v.Term, EndingBefore(d0401))
+}
+
+func TestOpenDateRangeSQL(t *testing.T) {
+	cases := []struct {
+		r   OpenDateRange
+		exp driver.Value
+	}{
+		{r: StartingFrom(d0325), exp: "[2015-03-25,)"},
+		{r: EndingBefore(d0401), exp: "(,2015-04-01)"},
+		{r: OpenDateRange{}, exp: "(,)"},
+		{r: OpenDateRangeOf(BetweenDates(d0320, d0401)), exp: "[2015-03-20,2015-04-01)"},
+		{r: OpenDateRangeOf(EmptyRange(0)), exp: "empty"},
+	}
+
+	for i, c := range cases {
+		v, err := c.r.Value()
+		isEq(t, i, err, nil)
+		isEq(t, i, v, c.exp)
+
+		var r OpenDateRange
+		isEq(t, i, r.Scan(v), nil)
+		isEq(t, i, r, c.r)
+	}
+
+	var r OpenDateRange
+	isEq(t, 0, r.Scan("[2015-03-25,infinity]"), nil)
+	isEq(t, 0, r, StartingFrom(d0325))
+	isEq(t, 0, r.Scan("(-infinity,2015-03-31]"), nil)
+	isEq(t, 0, r, EndingBefore(d0401))
+	isEq(t, 0, r.Scan(nil), nil)
+	isEq(t, 0, r, EndingBefore(d0401))
+	isEq(t, 0, r.Scan("[2015-04-01,2015-03-25)") != nil, true)
+	isEq(t, 0, r.Scan(42) != nil, true)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import "fmt"
+
+// Relation is one of the thirteen relations between two intervals identified by
+// J. F. Allen ("Maintaining knowledge about temporal intervals", 1983). Exactly one
+// relation holds between any two intervals.
+//
+// The relations are defined for half-open ranges, so a range that ends on the date
+// another begins "meets" it: the two are adjacent and share no dates.
+type Relation int
+
+const (
+	// Precedes: A ends before B starts, with a gap between them.
+	Precedes Relation = iota
+	// Meets: A ends exactly where B starts.
+	Meets
+	// Overlaps: A starts first and ends inside B.
+	Overlaps
+	// Starts: A and B start together but A ends first.
+	Starts
+	// During: A lies strictly inside B.
+	During
+	// Finishes: A and B end together but A starts later.
+	Finishes
+	// Equals: A and B are the same.
+	Equals
+	// FinishedBy is the inverse of Finishes.
+	FinishedBy
+	// Contains is the inverse of During.
+	Contains
+	// StartedBy is the inverse of Starts.
+	StartedBy
+	// OverlappedBy is the inverse of Overlaps.
+	OverlappedBy
+	// MetBy is the inverse of Meets.
+	MetBy
+	// PrecededBy is the inverse of Precedes.
+	PrecededBy
+)
+
+var relationNames = [...]string{
+	"precedes", "meets", "overlaps", "starts", "during", "finishes", "equals",
+	"finished by", "contains", "started by", "overlapped by", "met by", "preceded by",
+}
+
+// String returns the name of the relation, e.g. "overlapped by".
+func (r Relation) String() string {
+	if r < Precedes || r > PrecededBy {
+		return fmt.Sprintf("Relation(%d)", int(r))
+	}
+	return relationNames[r]
+}
+
+// Inverse returns the relation that holds when the two intervals are swapped, so that
+// if A.RelationTo(B) is r, B.RelationTo(A) is r.Inverse().
+func (r Relation) Inverse() Relation {
+	return PrecededBy - r
+}
+
+// IsDisjoint is true for the relations in which the intervals have nothing in common,
+// i.e. Precedes, Meets, MetBy and PrecededBy.
+func (r Relation) IsDisjoint() bool {
+	return r == Precedes || r == Meets || r == MetBy || r == PrecededBy
+}
+
+// relation classifies two intervals given by their start and (exclusive) end points,
+// which must be ordered so that s <= e.
+func relation[T ~int64](s1, e1, s2, e2 T) Relation {
+	switch {
+	case s1 == s2 && e1 == e2:
+		return Equals
+	case e1 < s2:
+		return Precedes
+	case e1 == s2:
+		return Meets
+	case e2 < s1:
+		return PrecededBy
+	case e2 == s1:
+		return MetBy
+	case s1 == s2 && e1 < e2:
+		return Starts
+	case s1 == s2:
+		return StartedBy
+	case e1 == e2 && s1 > s2:
+		return Finishes
+	case e1 == e2:
+		return FinishedBy
+	case s1 > s2 && e1 < e2:
+		return During
+	case s1 < s2 && e1 > e2:
+		return Contains
+	case s1 < s2:
+		return Overlaps
+	default:
+		return OverlappedBy
+	}
+}
+
+// RelationTo classifies how this date range relates to another, as one of Allen's
+// thirteen interval relations. Empty ranges are treated as points; for example, an
+// empty range on the start date of another range meets it.
+func (dateRange DateRange) RelationTo(otherRange DateRange) Relation {
+	return relation(dateRange.start, dateRange.End(), otherRange.start, otherRange.End())
+}
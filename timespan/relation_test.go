@@ -0,0 +1,57 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"testing"
+)
+
+func TestDateRangeRelationTo(t *testing.T) {
+	b := BetweenDates(d0325, d0331)
+
+	cases := []struct {
+		a   DateRange
+		exp Relation
+	}{
+		{a: BetweenDates(d0320, d0321), exp: Precedes},
+		{a: BetweenDates(d0320, d0325), exp: Meets},
+		{a: BetweenDates(d0320, d0327), exp: Overlaps},
+		{a: BetweenDates(d0325, d0327), exp: Starts},
+		{a: BetweenDates(d0326, d0328), exp: During},
+		{a: BetweenDates(d0327, d0331), exp: Finishes},
+		{a: BetweenDates(d0325, d0331), exp: Equals},
+		{a: BetweenDates(d0320, d0331), exp: FinishedBy},
+		{a: BetweenDates(d0320, d0401), exp: Contains},
+		{a: BetweenDates(d0325, d0401), exp: StartedBy},
+		{a: BetweenDates(d0327, d0401), exp: OverlappedBy},
+		{a: BetweenDates(d0331, d0401), exp: MetBy},
+		{a: BetweenDates(d0401, d0402), exp: PrecededBy},
+		// empty ranges are points
+		{a: EmptyRange(d0325), exp: Meets},
+		{a: EmptyRange(d0327), exp: During},
+		{a: EmptyRange(d0331), exp: MetBy},
+		{a: EmptyRange(d0320), exp: Precedes},
+	}
+
+	for i, c := range cases {
+		r := c.a.RelationTo(b)
+		isEq(t, i, r, c.exp, c.a)
+		isEq(t, i, b.RelationTo(c.a), c.exp.Inverse(), c.a)
+		if !c.a.IsEmpty() {
+			isEq(t, i, r.IsDisjoint(), !c.a.Overlaps(b), c.a)
+		}
+	}
+
+	isEq(t, 0, EmptyRange(d0325).RelationTo(EmptyRange(d0325)), Equals)
+}
+
+func TestRelationString(t *testing.T) {
+	isEq(t, 0, Precedes.String(), "precedes")
+	isEq(t, 0, OverlappedBy.String(), "overlapped by")
+	isEq(t, 0, PrecededBy.String(), "preceded by")
+	isEq(t, 0, Relation(13).String(), "Relation(13)")
+	isEq(t, 0, Equals.Inverse(), Equals)
+	isEq(t, 0, During.Inverse(), Contains)
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+// SplitUnit is a calendar unit used for splitting date ranges into buckets.
+type SplitUnit int
+
+const (
+	// Week is a week of seven days; by default, weeks start on Monday as per ISO 8601.
+	Week SplitUnit = iota
+	// Month is a calendar month.
+	Month
+	// Quarter is a calendar quarter, i.e. three months starting in January, April,
+	// July or October.
+	Quarter
+	// Year is a calendar year.
+	Year
+)
+
+var splitUnitNames = [...]string{"week", "month", "quarter", "year"}
+
+// String returns the name of the unit, e.g. "quarter".
+func (unit SplitUnit) String() string {
+	if unit < Week || unit > Year {
+		return fmt.Sprintf("SplitUnit(%d)", int(unit))
+	}
+	return splitUnitNames[unit]
+}
+
+// Bucket is one part of a date range that has been split into calendar units.
+type Bucket struct {
+	Range DateRange
+
+	// Partial is true when the range has been clipped, so that it covers only part
+	// of its calendar unit. This can only happen for the first and last buckets.
+	Partial bool
+}
+
+// SplitBy splits the date range into consecutive buckets aligned to a calendar unit.
+// The first and last buckets are clipped to the date range, and are then marked as
+// partial. Weeks start on Monday; use SplitByWeeks for other weeks.
+//
+// An empty date range gives no buckets.
+func (dateRange DateRange) SplitBy(unit SplitUnit) []Bucket {
+	return dateRange.splitBy(unit, time.Monday)
+}
+
+// SplitByWeeks splits the date range into consecutive weeks that start on a given day.
+// The first and last buckets are clipped to the date range, and are then marked as
+// partial.
+//
+// An empty date range gives no buckets.
+func (dateRange DateRange) SplitByWeeks(startDay time.Weekday) []Bucket {
+	return dateRange.splitBy(Week, startDay)
+}
+
+func (dateRange DateRange) splitBy(unit SplitUnit, startDay time.Weekday) []Bucket {
+	var buckets []Bucket
+	end := dateRange.End()
+	for from := dateRange.start; from < end; {
+		unitStart := startOfUnit(from, unit, startDay)
+		unitEnd := endOfUnit(unitStart, unit)
+		to := min(unitEnd, end)
+		buckets = append(buckets, Bucket{
+			Range:   BetweenDates(from, to),
+			Partial: from != unitStart || to != unitEnd,
+		})
+		from = to
+	}
+	return buckets
+}
+
+// startOfUnit finds the first date of the calendar unit that contains d.
+func startOfUnit(d date.Date, unit SplitUnit, startDay time.Weekday) date.Date {
+	switch unit {
+	case Week:
+		return d - date.Date((d.Weekday()-startDay+7)%7)
+	case Month:
+		y, m, _ := d.Date()
+		return date.New(y, m, 1)
+	case Quarter:
+		y, m, _ := d.Date()
+		return date.New(y, m-(m-1)%3, 1)
+	case Year:
+		return date.New(d.Year(), time.January, 1)
+	}
+	panic(fmt.Sprintf("timespan: unknown %v", unit))
+}
+
+// endOfUnit finds the first date after the calendar unit that starts on d.
+func endOfUnit(d date.Date, unit SplitUnit) date.Date {
+	switch unit {
+	case Week:
+		return d + 7
+	case Month:
+		return d.AddDate(0, 1, 0)
+	case Quarter:
+		return d.AddDate(0, 3, 0)
+	case Year:
+		return d.AddDate(1, 0, 0)
+	}
+	panic(fmt.Sprintf("timespan: unknown %v", unit))
+}
+
+// Chunk splits the date range into consecutive ranges of maxDays each; the last range
+// may be shorter. This is useful for paging through services that limit the number
+// of days per request. An empty date range gives no ranges; if maxDays is not positive,
+// the result is nil.
+func (dateRange DateRange) Chunk(maxDays PeriodOfDays) []DateRange {
+	if maxDays <= 0 {
+		return nil
+	}
+
+	chunks := make([]DateRange, 0, (dateRange.days+maxDays-1)/maxDays)
+	for from := dateRange.start; from < dateRange.End(); from += date.Date(maxDays) {
+		chunks = append(chunks, BetweenDates(from, min(from+date.Date(maxDays), dateRange.End())))
+	}
+	return chunks
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// DaySpan is the part of a time span that falls on one calendar day in some location.
+type DaySpan struct {
+	// Date is the local calendar date.
+	Date date.Date
+	// Span is the part of the time span on that date.
+	Span TimeSpan
+}
+
+// SplitByDayIn cuts the time span at each local midnight in the specified location,
+// giving one part per calendar date, in ascending order. The first and last parts may
+// be shorter than the others. The spans are in the specified location and the result is
+// normalised. An empty time span gives no parts.
+//
+// Days are not assumed to be 24 hours long: the day that the clocks change has 23 or 25
+// hours. In zones where the clocks change at midnight, so that some days start at 1am
+// say, each part starts at the first instant of its date.
+func (ts TimeSpan) SplitByDayIn(loc *time.Location) []DaySpan {
+	if ts.IsEmpty() {
+		return nil
+	}
+
+	no := ts.Normalise().In(loc)
+	end := no.End()
+
+	var result []DaySpan
+	from := no.mark
+	for d := date.NewAt(from); ; d++ {
+		next := (d + 1).StartOfDayIn(loc)
+		if !next.Before(end) {
+			return append(result, DaySpan{Date: d, Span: BetweenTimes(from, end)})
+		}
+		result = append(result, DaySpan{Date: d, Span: BetweenTimes(from, next)})
+		from = next
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func bucketsString(buckets []Bucket) string {
+	s := make([]string, len(buckets))
+	for i, b := range buckets {
+		s[i] = fmt.Sprintf("%s..%s", b.Range.Start(), b.Range.End())
+		if b.Partial {
+			s[i] += "*"
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func TestSplitBy(t *testing.T) {
+	cases := []struct {
+		dr   DateRange
+		unit SplitUnit
+		exp  string
+	}{
+		// 2015-03-20 is a Friday
+		{dr: BetweenDates(d0320, d0404), unit: Week,
+			exp: "2015-03-20..2015-03-23* 2015-03-23..2015-03-30 2015-03-30..2015-04-04*"},
+		{dr: BetweenDates(New(2015, time.March, 23), New(2015, time.April, 6)), unit: Week,
+			exp: "2015-03-23..2015-03-30 2015-03-30..2015-04-06"},
+		{dr: BetweenDates(d0320, d0321), unit: Week,
+			exp: "2015-03-20..2015-03-21*"},
+		{dr: BetweenDates(d0320, New(2015, time.June, 2)), unit: Month,
+			exp: "2015-03-20..2015-04-01* 2015-04-01..2015-05-01 2015-05-01..2015-06-01 2015-06-01..2015-06-02*"},
+		{dr: NewMonthOf(2016, time.February), unit: Month,
+			exp: "2016-02-01..2016-03-01"},
+		{dr: BetweenDates(New(2015, time.February, 10), New(2016, time.January, 1)), unit: Quarter,
+			exp: "2015-02-10..2015-04-01* 2015-04-01..2015-07-01 2015-07-01..2015-10-01 2015-10-01..2016-01-01"},
+		{dr: BetweenDates(New(2014, time.December, 31), New(2016, time.July, 1)), unit: Year,
+			exp: "2014-12-31..2015-01-01* 2015-01-01..2016-01-01 2016-01-01..2016-07-01*"},
+		{dr: EmptyRange(d0320), unit: Month, exp: ""},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, bucketsString(c.dr.SplitBy(c.unit)), c.exp, c.unit)
+	}
+}
+
+func TestSplitByWeeks(t *testing.T) {
+	dr := BetweenDates(d0320, d0404)
+	isEq(t, 0, bucketsString(dr.SplitByWeeks(time.Sunday)),
+		"2015-03-20..2015-03-22* 2015-03-22..2015-03-29 2015-03-29..2015-04-04*")
+	isEq(t, 0, bucketsString(dr.SplitByWeeks(time.Friday)),
+		"2015-03-20..2015-03-27 2015-03-27..2015-04-03 2015-04-03..2015-04-04*")
+	isEq(t, 0, bucketsString(dr.SplitByWeeks(time.Saturday)),
+		"2015-03-20..2015-03-21* 2015-03-21..2015-03-28 2015-03-28..2015-04-04")
+}
+
+func TestSplitUnitString(t *testing.T) {
+	isEq(t, 0, Week.String(), "week")
+	isEq(t, 0, Year.String(), "year")
+	isEq(t, 0, SplitUnit(9).String(), "SplitUnit(9)")
+}
+
+func TestChunk(t *testing.T) {
+	cases := []struct {
+		dr      DateRange
+		maxDays PeriodOfDays
+		exp     []DateRange
+	}{
+		{dr: BetweenDates(d0320, d0401), maxDays: 5, exp: []DateRange{BetweenDates(d0320, d0325), BetweenDates(d0325, d0330), BetweenDates(d0330, d0401)}},
+		{dr: BetweenDates(d0320, d0330), maxDays: 5, exp: []DateRange{BetweenDates(d0320, d0325), BetweenDates(d0325, d0330)}},
+		{dr: BetweenDates(d0320, d0330), maxDays: 31, exp: []DateRange{BetweenDates(d0320, d0330)}},
+		{dr: EmptyRange(d0320), maxDays: 5, exp: []DateRange{}},
+		{dr: BetweenDates(d0320, d0330), maxDays: 0, exp: nil},
+	}
+
+	for i, c := range cases {
+		chunks := c.dr.Chunk(c.maxDays)
+		isEq(t, i, fmt.Sprint(chunks), fmt.Sprint(c.exp))
+
+		// the chunks always cover the range exactly
+		if c.maxDays > 0 {
+			var total PeriodOfDays
+			for j, ch := range chunks {
+				isEq(t, i, ch.Days() <= c.maxDays, true, j)
+				total += ch.Days()
+			}
+			isEq(t, i, total, c.dr.Days())
+		}
+	}
+
+	isEq(t, 0, len(NewYearOf(2015).Chunk(31)), 12)
+}
+
+func TestSplitByDayIn(t *testing.T) {
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+
+	cases := []struct {
+		ts  TimeSpan
+		loc *time.Location
+		exp []string
+	}{
+		{
+			ts:  ZeroTimeSpan(time.Date(2015, 3, 27, 10, 0, 0, 0, time.UTC)),
+			loc: time.UTC,
+		},
+		{
+			ts:  BetweenTimes(time.Date(2015, 3, 27, 10, 0, 0, 0, time.UTC), time.Date(2015, 3, 27, 12, 0, 0, 0, time.UTC)),
+			loc: time.UTC,
+			exp: []string{"2015-03-27 2h0m0s"},
+		},
+		{
+			ts:  BetweenTimes(time.Date(2015, 3, 27, 10, 0, 0, 0, time.UTC), time.Date(2015, 3, 29, 0, 0, 0, 0, time.UTC)),
+			loc: time.UTC,
+			exp: []string{"2015-03-27 14h0m0s", "2015-03-28 24h0m0s"},
+		},
+		{
+			// negative duration
+			ts:  TimeSpanOf(time.Date(2015, 3, 29, 6, 0, 0, 0, time.UTC), -30*time.Hour),
+			loc: time.UTC,
+			exp: []string{"2015-03-28 24h0m0s", "2015-03-29 6h0m0s"},
+		},
+		{
+			// London clocks go forward on 29th March 2015, which has 23 hours
+			ts:  BetweenTimes(time.Date(2015, 3, 28, 12, 0, 0, 0, time.UTC), time.Date(2015, 3, 30, 12, 0, 0, 0, time.UTC)),
+			loc: london,
+			exp: []string{"2015-03-28 12h0m0s", "2015-03-29 23h0m0s", "2015-03-30 13h0m0s"},
+		},
+		{
+			// London clocks go back on 25th October 2015, which has 25 hours
+			ts:  BetweenTimes(time.Date(2015, 10, 24, 12, 0, 0, 0, time.UTC), time.Date(2015, 10, 26, 12, 0, 0, 0, time.UTC)),
+			loc: london,
+			exp: []string{"2015-10-24 11h0m0s", "2015-10-25 25h0m0s", "2015-10-26 12h0m0s"},
+		},
+		{
+			// in São Paulo, the clocks went forward at midnight on 4th November 2018,
+			// so that day started at 1am and had 23 hours
+			ts:  BetweenTimes(time.Date(2018, 11, 3, 12, 0, 0, 0, saoPaulo), time.Date(2018, 11, 5, 12, 0, 0, 0, saoPaulo)),
+			loc: saoPaulo,
+			exp: []string{"2018-11-03 12h0m0s", "2018-11-04 23h0m0s", "2018-11-05 12h0m0s"},
+		},
+		{
+			// in São Paulo, the clocks went back at midnight on 17th February 2019,
+			// so 16th February had 25 hours
+			ts:  BetweenTimes(time.Date(2019, 2, 16, 12, 0, 0, 0, saoPaulo), time.Date(2019, 2, 17, 12, 0, 0, 0, saoPaulo)),
+			loc: saoPaulo,
+			exp: []string{"2019-02-16 13h0m0s", "2019-02-17 12h0m0s"},
+		},
+	}
+
+	for i, c := range cases {
+		parts := c.ts.SplitByDayIn(c.loc)
+		var s []string
+		var total time.Duration
+		for j, p := range parts {
+			s = append(s, fmt.Sprintf("%s %s", p.Date, p.Span.Duration()))
+			total += p.Span.Duration()
+			isEq(t, i, p.Span.Start().Location(), c.loc)
+			isEq(t, i, NewAt(p.Span.Start()), p.Date, j)
+			if j > 0 {
+				isEq(t, i, p.Span.Start(), parts[j-1].Span.End(), j)
+			}
+		}
+		isEq(t, i, strings.Join(s, ", "), strings.Join(c.exp, ", "))
+		isEq(t, i, total, c.ts.Normalise().Duration())
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rickb777/date/v2"
+)
+
+// These methods allow DateRange and TimeSpan to be stored in an SQL database using
+// range types, such as PostgreSQL daterange, tsrange and tstzrange, by implementing the
+// database/sql/driver interfaces. The values are range literals such as
+// "[2024-01-01,2024-02-01)"; see https://www.postgresql.org/docs/current/rangetypes.html
+
+// Scan parses a range literal such as "[2024-01-01,2024-02-01)". All four combinations
+// of inclusive '[' ']' and exclusive '(' ')' bounds are accepted; the result is normalised
+// to the half-open form of DateRange. The literal "empty" gives the zero DateRange.
+//
+// Unbounded (infinite) lower and upper bounds are mapped to date.Min() and date.Max()
+// respectively. Use OpenDateRange to represent unbounded ranges without these sentinels.
+//
+// This implements sql.Scanner https://golang.org/pkg/database/sql/#Scanner
+func (dateRange *DateRange) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var r OpenDateRange
+	if err := r.Scan(value); err != nil {
+		return err
+	}
+
+	start, end := date.Min(), date.Max()
+	if r.hasStart {
+		start = r.start
+	}
+	if r.hasEnd {
+		end = r.end
+	}
+
+	*dateRange = BetweenDates(start, end)
+	return nil
+}
+
+// Value converts the date range to a range literal such as "[2024-01-01,2024-02-01)".
+// Empty ranges are converted to "empty", so their start date is lost. A start of
+// date.Min() or an end of date.Max() is converted to an unbounded bound.
+//
+// This implements driver.Valuer https://golang.org/pkg/database/sql/driver/#Valuer
+func (dateRange DateRange) Value() (driver.Value, error) {
+	if dateRange.days == 0 {
+		return "empty", nil
+	}
+
+	buf := &strings.Builder{}
+	if dateRange.start == date.Min() {
+		buf.WriteString("(,")
+	} else {
+		buf.WriteByte('[')
+		buf.WriteString(dateRange.start.String())
+		buf.WriteByte(',')
+	}
+	if dateRange.End() != date.Max() {
+		buf.WriteString(dateRange.End().String())
+	}
+	buf.WriteByte(')')
+	return buf.String(), nil
+}
+
+// sqlTimestampLayout is the layout used for the bounds of time span range literals.
+const sqlTimestampLayout = "2006-01-02 15:04:05.999999Z07:00"
+
+// Scan parses a range literal such as
+//
+//	["2024-01-01 10:00:00+00","2024-01-01 11:30:00+00")
+//
+// All four combinations of inclusive '[' ']' and exclusive '(' ')' bounds are accepted;
+// the result is normalised to a half-open span. Because PostgreSQL holds times to the
+// nearest microsecond, an exclusive lower bound or an inclusive upper bound is moved
+// later by one microsecond. The literal "empty" gives the zero TimeSpan.
+//
+// Timestamps without a time zone are placed in the location of the receiver's
+// existing mark time, as for UnmarshalText. Unbounded (infinite) bounds cannot be
+// represented by a TimeSpan, so they cause an error.
+//
+// This implements sql.Scanner https://golang.org/pkg/database/sql/#Scanner
+func (ts *TimeSpan) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	s, err := rangeString(value)
+	if err != nil {
+		return err
+	}
+
+	rl, err := parseRangeLiteral(s)
+	if err != nil {
+		return err
+	}
+
+	if rl.empty {
+		*ts = TimeSpan{}
+		return nil
+	}
+
+	if rl.lowerInfinite || rl.upperInfinite {
+		return fmt.Errorf("timespan: range %q is unbounded", s)
+	}
+
+	loc := ts.mark.Location()
+
+	start, err := parseSQLTimestamp(rl.lower, loc)
+	if err != nil {
+		return err
+	}
+	if !rl.lowerInclusive {
+		start = start.Add(time.Microsecond)
+	}
+
+	end, err := parseSQLTimestamp(rl.upper, loc)
+	if err != nil {
+		return err
+	}
+	if rl.upperInclusive {
+		end = end.Add(time.Microsecond)
+	}
+
+	if end.Before(start) {
+		return fmt.Errorf("timespan: range %q has its upper bound before its lower bound", s)
+	}
+
+	*ts = BetweenTimes(start, end)
+	return nil
+}
+
+// Value converts the time span to a range literal such as
+//
+//	["2024-01-01 10:00:00Z","2024-01-01 11:30:00Z")
+//
+// The times are written with their UTC offset and to the nearest microsecond. Empty
+// spans are converted to "empty", so their start time is lost.
+//
+// This implements driver.Valuer https://golang.org/pkg/database/sql/driver/#Valuer
+func (ts TimeSpan) Value() (driver.Value, error) {
+	if ts.IsEmpty() {
+		return "empty", nil
+	}
+
+	ts = ts.Normalise()
+	return fmt.Sprintf(`[%q,%q)`,
+		ts.Start().Round(time.Microsecond).Format(sqlTimestampLayout),
+		ts.End().Round(time.Microsecond).Format(sqlTimestampLayout)), nil
+}
+
+func parseSQLTimestamp(s string, loc *time.Location) (time.Time, error) {
+	s = strings.Replace(s, "T", " ", 1)
+	for _, layout := range []string{
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999Z07",
+		"2006-01-02 15:04:05.999999999Z07:00:00",
+	} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05.999999999", s, loc)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func rangeString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("%T %+v is not a meaningful range", value, value)
+}
+
+// rangeLiteral holds the parts of a range literal. Bounds are unquoted.
+type rangeLiteral struct {
+	lower, upper                   string
+	lowerInclusive, upperInclusive bool
+	lowerInfinite, upperInfinite   bool
+	empty                          bool
+}
+
+// parseRangeLiteral parses a range literal. Bounds may be double-quoted, in which case
+// they may contain backslash escapes and doubled double-quotes. A missing bound, or one
+// of "infinity" or "-infinity", is unbounded.
+func parseRangeLiteral(s string) (rangeLiteral, error) {
+	var rl rangeLiteral
+
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		rl.empty = true
+		return rl, nil
+	}
+
+	if len(s) < 3 {
+		return rl, fmt.Errorf("timespan: %q is not a range literal", s)
+	}
+
+	switch s[0] {
+	case '[':
+		rl.lowerInclusive = true
+	case '(':
+	default:
+		return rl, fmt.Errorf("timespan: %q is not a range literal", s)
+	}
+
+	switch s[len(s)-1] {
+	case ']':
+		rl.upperInclusive = true
+	case ')':
+	default:
+		return rl, fmt.Errorf("timespan: %q is not a range literal", s)
+	}
+
+	lower, rest, err := parseRangeBound(s[1 : len(s)-1])
+	if err != nil || len(rest) == 0 || rest[0] != ',' {
+		return rl, fmt.Errorf("timespan: %q is not a range literal", s)
+	}
+
+	upper, rest, err := parseRangeBound(rest[1:])
+	if err != nil || rest != "" {
+		return rl, fmt.Errorf("timespan: %q is not a range literal", s)
+	}
+
+	rl.lower, rl.lowerInfinite = lower, lower == "" || lower == "-infinity"
+	rl.upper, rl.upperInfinite = upper, upper == "" || upper == "infinity"
+	return rl, nil
+}
+
+// parseRangeBound parses one bound, which is either double-quoted or runs up to the next
+// comma or the end of the string. It returns the remainder of the string. Spaces around
+// the bound are ignored.
+func parseRangeBound(s string) (string, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if s == "" || s[0] != '"' {
+		i := strings.IndexByte(s, ',')
+		if i < 0 {
+			return strings.TrimSpace(s), "", nil
+		}
+		return strings.TrimSpace(s[:i]), s[i:], nil
+	}
+
+	buf := &strings.Builder{}
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s):
+			i++
+			buf.WriteByte(s[i])
+		case c == '"' && i+1 < len(s) && s[i+1] == '"':
+			i++
+			buf.WriteByte('"')
+		case c == '"':
+			return buf.String(), strings.TrimLeft(s[i+1:], " "), nil
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quote")
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	. "github.com/rickb777/date/v2"
+)
+
+func TestDateRangeScan(t *testing.T) {
+	cases := []struct {
+		v   interface{}
+		exp DateRange
+	}{
+		{v: "[2015-03-20,2015-04-01)", exp: BetweenDates(d0320, d0401)},
+		{v: []byte("[2015-03-20,2015-04-01)"), exp: BetweenDates(d0320, d0401)},
+		{v: "[2015-03-20,2015-03-31]", exp: BetweenDates(d0320, d0401)},
+		{v: "(2015-03-19,2015-04-01)", exp: BetweenDates(d0320, d0401)},
+		{v: "(2015-03-19,2015-03-31]", exp: BetweenDates(d0320, d0401)},
+		{v: ` [ "2015-03-20" , "2015-04-01" ) `, exp: BetweenDates(d0320, d0401)},
+		{v: "[2015-03-20,2015-03-20)", exp: EmptyRange(d0320)},
+		{v: "empty", exp: DateRange{}},
+		{v: "EMPTY", exp: DateRange{}},
+		{v: "[2015-03-20,)", exp: BetweenDates(d0320, Max())},
+		{v: "[2015-03-20,infinity)", exp: BetweenDates(d0320, Max())},
+		{v: "(,2015-04-01)", exp: BetweenDates(Min(), d0401)},
+		{v: "[-infinity,2015-04-01)", exp: BetweenDates(Min(), d0401)},
+	}
+
+	for i, c := range cases {
+		var dr DateRange
+		err := dr.Scan(c.v)
+		isEq(t, i, err, nil, c.v)
+		isEq(t, i, dr, c.exp, c.v)
+	}
+
+	dr := OneDayRange(d0320)
+	isEq(t, 0, dr.Scan(nil), nil)
+	isEq(t, 0, dr, OneDayRange(d0320))
+}
+
+func TestDateRangeScanErrors(t *testing.T) {
+	cases := []interface{}{
+		1,
+		"",
+		"2015-03-20,2015-04-01",
+		"[2015-03-20,2015-04-01",
+		"[2015-03-20;2015-04-01)",
+		"[2015-03-20,2015-04-01,2015-05-01)",
+		`["2015-03-20,2015-04-01)`,
+		"[2015-03-20,x)",
+		"[x,2015-04-01)",
+		"[2015-04-01,2015-03-20)",
+	}
+
+	for i, c := range cases {
+		var dr DateRange
+		isEq(t, i, dr.Scan(c) != nil, true, c)
+	}
+}
+
+func TestDateRangeValue(t *testing.T) {
+	cases := []struct {
+		dr  DateRange
+		exp driver.Value
+	}{
+		{dr: BetweenDates(d0320, d0401), exp: "[2015-03-20,2015-04-01)"},
+		{dr: EmptyRange(d0320), exp: "empty"},
+		{dr: BetweenDates(d0320, Max()), exp: "[2015-03-20,)"},
+		{dr: BetweenDates(Min(), d0401), exp: "(,2015-04-01)"},
+		{dr: BetweenDates(Min(), Max()), exp: "(,)"},
+	}
+
+	for i, c := range cases {
+		v, err := c.dr.Value()
+		isEq(t, i, err, nil)
+		isEq(t, i, v, c.exp)
+
+		var dr DateRange
+		isEq(t, i, dr.Scan(v), nil)
+		if !c.dr.IsEmpty() {
+			isEq(t, i, dr, c.dr)
+		}
+	}
+}
+
+func TestTimeSpanScan(t *testing.T) {
+	t10 := time.Date(2015, 3, 20, 10, 0, 0, 0, time.UTC)
+	t11 := time.Date(2015, 3, 20, 11, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		v   interface{}
+		exp TimeSpan
+	}{
+		{v: `["2015-03-20 10:00:00+00","2015-03-20 11:30:00+00")`, exp: BetweenTimes(t10, t11)},
+		{v: []byte(`["2015-03-20 10:00:00+00","2015-03-20 11:30:00+00")`), exp: BetweenTimes(t10, t11)},
+		{v: `["2015-03-20 11:00:00+01","2015-03-20 12:30:00+01:00")`, exp: BetweenTimes(t10, t11)},
+		{v: `["2015-03-20 10:00:00Z","2015-03-20T11:30:00Z")`, exp: BetweenTimes(t10, t11)},
+		{v: `["2015-03-20 10:00:00.5+00","2015-03-20 11:30:00.25+00")`, exp: BetweenTimes(t10.Add(500*time.Millisecond), t11.Add(250*time.Millisecond))},
+		{v: `("2015-03-20 10:00:00+00","2015-03-20 11:30:00+00"]`, exp: BetweenTimes(t10.Add(time.Microsecond), t11.Add(time.Microsecond))},
+		{v: `[2015-03-20 10:00:00,2015-03-20 11:30:00)`, exp: BetweenTimes(t10, t11)},
+		{v: `empty`, exp: TimeSpan{}},
+	}
+
+	for i, c := range cases {
+		var ts TimeSpan
+		err := ts.Scan(c.v)
+		isEq(t, i, err, nil, c.v)
+		isEq(t, i, ts.Equal(c.exp), true, ts, c.v)
+	}
+
+	// the location of the receiver is used for timestamps without a zone
+	ts := ZeroTimeSpan(time.Date(2000, 1, 1, 0, 0, 0, 0, london))
+	err := ts.Scan(`[2015-06-20 10:00:00,2015-06-20 11:30:00)`)
+	isEq(t, 0, err, nil)
+	isEq(t, 0, ts.Start().Format(time.RFC3339), "2015-06-20T10:00:00+01:00")
+}
+
+func TestTimeSpanScanErrors(t *testing.T) {
+	cases := []interface{}{
+		1,
+		"[x,y)",
+		`["2015-03-20 10:00:00+00",)`,
+		`(,"2015-03-20 10:00:00+00")`,
+		`["2015-03-20 11:00:00+00","2015-03-20 10:00:00+00")`,
+	}
+
+	for i, c := range cases {
+		var ts TimeSpan
+		isEq(t, i, ts.Scan(c) != nil, true, c)
+	}
+}
+
+func TestTimeSpanValue(t *testing.T) {
+	t10 := time.Date(2015, 3, 20, 10, 0, 0, 123456789, time.UTC)
+
+	cases := []struct {
+		ts  TimeSpan
+		exp driver.Value
+	}{
+		{ts: TimeSpanOf(t10, time.Hour), exp: `["2015-03-20 10:00:00.123457Z","2015-03-20 11:00:00.123457Z")`},
+		{ts: TimeSpanOf(t10.Add(time.Hour), -time.Hour), exp: `["2015-03-20 10:00:00.123457Z","2015-03-20 11:00:00.123457Z")`},
+		{ts: TimeSpanOf(time.Date(2015, 6, 20, 10, 0, 0, 0, london), time.Hour), exp: `["2015-06-20 10:00:00+01:00","2015-06-20 11:00:00+01:00")`},
+		{ts: ZeroTimeSpan(t10), exp: "empty"},
+	}
+
+	for i, c := range cases {
+		v, err := c.ts.Value()
+		isEq(t, i, err, nil)
+		isEq(t, i, v, c.exp)
+
+		if !c.ts.IsEmpty() {
+			var ts TimeSpan
+			isEq(t, i, ts.Scan(v), nil)
+			isEq(t, i, ts.Duration(), c.ts.Normalise().Duration())
+		}
+	}
+}
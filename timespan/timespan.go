@@ -162,6 +162,67 @@ func (ts TimeSpan) Merge(other TimeSpan) TimeSpan {
 	}
 }
 
+// Overlaps tests whether the two time spans have at least one instant in common.
+// Spans that merely abut do not overlap. Empty spans never overlap anything.
+func (ts TimeSpan) Overlaps(other TimeSpan) bool {
+	if ts.duration == 0 || other.duration == 0 {
+		return false
+	}
+	return ts.Start().Before(other.End()) && other.Start().Before(ts.End())
+}
+
+// Intersect returns the time span that is in both time spans. If they do not overlap, the
+// result is an empty span at the later of the two start times. The result is normalised
+// and is in the location of ts.
+func (ts TimeSpan) Intersect(other TimeSpan) TimeSpan {
+	loc := ts.mark.Location()
+	maxStart := latest(ts.Start(), other.Start()).In(loc)
+	minEnd := earliest(ts.End(), other.End())
+	if !ts.Overlaps(other) {
+		return ZeroTimeSpan(maxStart)
+	}
+	return TimeSpan{maxStart, minEnd.Sub(maxStart)}
+}
+
+// Subtract returns the parts of this time span that are not in the other span. The
+// result contains zero, one or two non-empty spans, in ascending order: there are two
+// when the other span lies strictly inside this one. The results are normalised and
+// are in the location of ts.
+func (ts TimeSpan) Subtract(other TimeSpan) []TimeSpan {
+	if ts.duration == 0 {
+		return nil
+	}
+
+	ts = ts.Normalise()
+	if !ts.Overlaps(other) {
+		return []TimeSpan{ts}
+	}
+
+	loc := ts.mark.Location()
+	var result []TimeSpan
+	if ts.Start().Before(other.Start()) {
+		result = append(result, BetweenTimes(ts.Start(), other.Start().In(loc)))
+	}
+	if other.End().Before(ts.End()) {
+		result = append(result, BetweenTimes(other.End().In(loc), ts.End()))
+	}
+	return result
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
 // RFC5545DateTimeLayout is the format string used by iCalendar (RFC5545). Note
 // that "Z" is to be appended when the time is UTC.
 //
@@ -394,3 +394,39 @@ func TestConversion3(t *testing.T) {
 	isEq(t, 0, ts1, ts2)
 	isEq(t, 0, ts1.Duration(), time.Hour*71)
 }
+
+func TestTSOverlapsIntersectSubtract(t *testing.T) {
+	h := func(n int) time.Time { return t0327.Add(time.Duration(n) * time.Hour) }
+	b := BetweenTimes(h(10), h(16))
+
+	cases := []struct {
+		a         TimeSpan
+		overlaps  bool
+		intersect TimeSpan
+		subtract  []TimeSpan
+	}{
+		{a: BetweenTimes(h(6), h(8)), intersect: ZeroTimeSpan(h(10)), subtract: []TimeSpan{b}},
+		{a: BetweenTimes(h(6), h(10)), intersect: ZeroTimeSpan(h(10)), subtract: []TimeSpan{b}},
+		{a: BetweenTimes(h(6), h(12)), overlaps: true, intersect: BetweenTimes(h(10), h(12)), subtract: []TimeSpan{BetweenTimes(h(12), h(16))}},
+		{a: BetweenTimes(h(11), h(12)), overlaps: true, intersect: BetweenTimes(h(11), h(12)), subtract: []TimeSpan{BetweenTimes(h(10), h(11)), BetweenTimes(h(12), h(16))}},
+		{a: TimeSpanOf(h(12), -time.Hour), overlaps: true, intersect: BetweenTimes(h(11), h(12)), subtract: []TimeSpan{BetweenTimes(h(10), h(11)), BetweenTimes(h(12), h(16))}},
+		{a: BetweenTimes(h(8), h(18)), overlaps: true, intersect: b},
+		{a: BetweenTimes(h(15), h(18)), overlaps: true, intersect: BetweenTimes(h(15), h(16)), subtract: []TimeSpan{BetweenTimes(h(10), h(15))}},
+		{a: BetweenTimes(h(16), h(18)), intersect: ZeroTimeSpan(h(16)), subtract: []TimeSpan{b}},
+		{a: ZeroTimeSpan(h(12)), intersect: ZeroTimeSpan(h(12)), subtract: []TimeSpan{b}},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, c.a.Overlaps(b), c.overlaps, c.a)
+		isEq(t, i, b.Overlaps(c.a), c.overlaps, c.a)
+		isEq(t, i, c.a.Intersect(b).Equal(c.intersect), true, c.a.Intersect(b))
+		isEq(t, i, b.Intersect(c.a).Equal(c.intersect), true, b.Intersect(c.a))
+		isEq(t, i, fmt.Sprint(b.Subtract(c.a)), fmt.Sprint(c.subtract), c.a)
+	}
+
+	isEq(t, 0, len(ZeroTimeSpan(h(12)).Subtract(b)), 0)
+
+	// results are in the location of the receiver
+	isEq(t, 0, b.In(london).Intersect(BetweenTimes(h(6), h(12))).Start().Location(), london)
+	isEq(t, 0, b.In(london).Subtract(BetweenTimes(h(11), h(12)))[1].Start().Location(), london)
+}
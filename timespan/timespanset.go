@@ -0,0 +1,174 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeSpanSet is a set of instants held as a sorted list of disjoint, non-empty, normalised
+// time spans. Spans that overlap or abut are coalesced, so every set has exactly one
+// representation. The zero value is an empty set.
+//
+// Like TimeSpan, TimeSpanSet is a value type: the operations return new sets and never
+// alter their receiver. Instants are compared irrespective of their location.
+type TimeSpanSet struct {
+	spans []TimeSpan
+}
+
+// NewTimeSpanSet constructs a set containing all the instants in the given spans.
+// Empty spans are ignored.
+func NewTimeSpanSet(spans ...TimeSpan) TimeSpanSet {
+	return TimeSpanSet{spans: normaliseSpans(slices.Clone(spans))}
+}
+
+// normaliseSpans normalises and sorts the spans, drops empty ones and coalesces any that
+// overlap or abut. The slice is altered in place.
+func normaliseSpans(spans []TimeSpan) []TimeSpan {
+	spans = slices.DeleteFunc(spans, TimeSpan.IsEmpty)
+	if len(spans) == 0 {
+		return nil
+	}
+
+	for i := range spans {
+		spans[i] = spans[i].Normalise()
+	}
+
+	slices.SortFunc(spans, func(a, b TimeSpan) int {
+		return a.mark.Compare(b.mark)
+	})
+
+	result := spans[:1]
+	for _, ts := range spans[1:] {
+		last := &result[len(result)-1]
+		if !ts.mark.After(last.End()) {
+			*last = TimeSpan{last.mark, latest(last.End(), ts.End()).Sub(last.mark)}
+		} else {
+			result = append(result, ts)
+		}
+	}
+	return result
+}
+
+// Spans returns the disjoint spans in the set, in ascending order.
+func (set TimeSpanSet) Spans() []TimeSpan {
+	return slices.Clone(set.spans)
+}
+
+// Len returns the number of disjoint spans in the set.
+func (set TimeSpanSet) Len() int {
+	return len(set.spans)
+}
+
+// IsEmpty returns true if the set contains no instants.
+func (set TimeSpanSet) IsEmpty() bool {
+	return len(set.spans) == 0
+}
+
+// Duration returns the total duration covered by the set.
+func (set TimeSpanSet) Duration() time.Duration {
+	var d time.Duration
+	for _, ts := range set.spans {
+		d += ts.duration
+	}
+	return d
+}
+
+// Bounds returns the smallest time span that contains every instant in the set. For an
+// empty set, this is the zero TimeSpan.
+func (set TimeSpanSet) Bounds() TimeSpan {
+	if len(set.spans) == 0 {
+		return TimeSpan{}
+	}
+	return BetweenTimes(set.spans[0].mark, set.spans[len(set.spans)-1].End())
+}
+
+// Contains tests whether the set contains a specified instant.
+func (set TimeSpanSet) Contains(t time.Time) bool {
+	i := sort.Search(len(set.spans), func(i int) bool {
+		return t.Before(set.spans[i].End())
+	})
+	return i < len(set.spans) && !t.Before(set.spans[i].mark)
+}
+
+// Union returns the set of instants that are in either set.
+func (set TimeSpanSet) Union(other TimeSpanSet) TimeSpanSet {
+	spans := make([]TimeSpan, 0, len(set.spans)+len(other.spans))
+	spans = append(spans, set.spans...)
+	spans = append(spans, other.spans...)
+	return TimeSpanSet{spans: normaliseSpans(spans)}
+}
+
+// Intersect returns the set of instants that are in both sets.
+func (set TimeSpanSet) Intersect(other TimeSpanSet) TimeSpanSet {
+	var result []TimeSpan
+	a, b := set.spans, other.spans
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].Overlaps(b[0]) {
+			result = append(result, a[0].Intersect(b[0]))
+		}
+		if a[0].End().Before(b[0].End()) {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return TimeSpanSet{spans: result}
+}
+
+// Difference returns the set of instants that are in this set but not in the other.
+func (set TimeSpanSet) Difference(other TimeSpanSet) TimeSpanSet {
+	return set.Intersect(other.Complement(set.Bounds()))
+}
+
+// Complement returns the instants within a window that are not in the set.
+// The results are in the location of the window.
+func (set TimeSpanSet) Complement(window TimeSpan) TimeSpanSet {
+	return TimeSpanSet{spans: set.Gaps(window)}
+}
+
+// Gaps returns the spans within a window that are not covered by the set, in
+// ascending order. The results are in the location of the window.
+func (set TimeSpanSet) Gaps(window TimeSpan) []TimeSpan {
+	window = window.Normalise()
+	loc := window.mark.Location()
+	end := window.End()
+
+	var result []TimeSpan
+	from := window.mark
+	for _, ts := range set.spans {
+		if !ts.mark.Before(end) {
+			break
+		}
+		if from.Before(ts.mark) {
+			result = append(result, BetweenTimes(from, ts.mark.In(loc)))
+		}
+		from = latest(from, ts.End().In(loc))
+	}
+	if from.Before(end) {
+		result = append(result, BetweenTimes(from, end))
+	}
+	return result
+}
+
+// FreeSlots returns the gaps within a window that last for at least a given duration,
+// in ascending order. Each gap is returned whole, so it may be longer than the duration.
+func (set TimeSpanSet) FreeSlots(window TimeSpan, d time.Duration) []TimeSpan {
+	return slices.DeleteFunc(set.Gaps(window), func(ts TimeSpan) bool {
+		return ts.duration < d
+	})
+}
+
+// String describes the set as a list of its spans.
+func (set TimeSpanSet) String() string {
+	s := make([]string, len(set.spans))
+	for i, ts := range set.spans {
+		s[i] = ts.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Rick Beton. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timespan
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func hr(n float64) time.Time {
+	return t0327.Add(time.Duration(n * float64(time.Hour)))
+}
+
+func spansString(spans []TimeSpan) string {
+	s := ""
+	for i, ts := range spans {
+		if i > 0 {
+			s += " "
+		}
+		s += fmt.Sprintf("%s-%s", ts.Start().Format("15:04"), ts.End().Format("15:04"))
+	}
+	return s
+}
+
+func TestNewTimeSpanSet(t *testing.T) {
+	cases := []struct {
+		in  []TimeSpan
+		exp string
+	}{
+		{in: nil, exp: ""},
+		{in: []TimeSpan{ZeroTimeSpan(hr(9))}, exp: ""},
+		{in: []TimeSpan{BetweenTimes(hr(12), hr(13)), BetweenTimes(hr(9), hr(10))}, exp: "09:00-10:00 12:00-13:00"},
+		{in: []TimeSpan{BetweenTimes(hr(9), hr(10)), BetweenTimes(hr(10), hr(11))}, exp: "09:00-11:00"},
+		{in: []TimeSpan{BetweenTimes(hr(9), hr(12)), TimeSpanOf(hr(11), -time.Hour)}, exp: "09:00-12:00"},
+		{in: []TimeSpan{BetweenTimes(hr(9), hr(11)), BetweenTimes(hr(10), hr(13)), BetweenTimes(hr(14), hr(15))}, exp: "09:00-13:00 14:00-15:00"},
+		{in: []TimeSpan{BetweenTimes(hr(9), hr(11)).In(london), BetweenTimes(hr(10.5), hr(12))}, exp: "09:00-12:00"},
+	}
+
+	for i, c := range cases {
+		s := NewTimeSpanSet(c.in...)
+		isEq(t, i, spansString(s.Spans()), c.exp)
+		isEq(t, i, s.IsEmpty(), c.exp == "")
+	}
+}
+
+func TestTimeSpanSetQueries(t *testing.T) {
+	s := NewTimeSpanSet(BetweenTimes(hr(9), hr(10)), BetweenTimes(hr(12), hr(13.5)))
+
+	isEq(t, 0, s.Len(), 2)
+	isEq(t, 0, s.Duration(), 150*time.Minute)
+	isEq(t, 0, s.Bounds().Equal(BetweenTimes(hr(9), hr(13.5))), true)
+	isEq(t, 0, TimeSpanSet{}.Bounds().IsEmpty(), true)
+	isEq(t, 0, s.String(), "[1h0m0s from 2015-03-27 09:00:00 to 2015-03-27 10:00:00, 1h30m0s from 2015-03-27 12:00:00 to 2015-03-27 13:30:00]")
+
+	cases := []struct {
+		t   time.Time
+		exp bool
+	}{
+		{t: hr(8.9)},
+		{t: hr(9), exp: true},
+		{t: hr(10).Add(-time.Nanosecond), exp: true},
+		{t: hr(10)},
+		{t: hr(12.5).In(london), exp: true},
+		{t: hr(13.5)},
+	}
+
+	for i, c := range cases {
+		isEq(t, i, s.Contains(c.t), c.exp, c.t)
+	}
+}
+
+func TestTimeSpanSetAlgebra(t *testing.T) {
+	a := NewTimeSpanSet(BetweenTimes(hr(9), hr(12)), BetweenTimes(hr(14), hr(17)))
+	b := NewTimeSpanSet(BetweenTimes(hr(11), hr(15)), BetweenTimes(hr(16), hr(18)))
+
+	isEq(t, 0, spansString(a.Union(b).Spans()), "09:00-18:00")
+	isEq(t, 0, spansString(a.Intersect(b).Spans()), "11:00-12:00 14:00-15:00 16:00-17:00")
+	isEq(t, 0, spansString(b.Intersect(a).Spans()), "11:00-12:00 14:00-15:00 16:00-17:00")
+	isEq(t, 0, spansString(a.Difference(b).Spans()), "09:00-11:00 15:00-16:00")
+	isEq(t, 0, spansString(b.Difference(a).Spans()), "12:00-14:00 17:00-18:00")
+	isEq(t, 0, a.Intersect(TimeSpanSet{}).IsEmpty(), true)
+	isEq(t, 0, spansString(a.Difference(TimeSpanSet{}).Spans()), spansString(a.Spans()))
+
+	w := BetweenTimes(hr(8), hr(20))
+	isEq(t, 0, spansString(a.Complement(w).Spans()), "08:00-09:00 12:00-14:00 17:00-20:00")
+	isEq(t, 0, a.Duration()+a.Complement(w).Duration(), w.Duration())
+}
+
+func TestTimeSpanSetGapsAndFreeSlots(t *testing.T) {
+	// on-call rota
+	s := NewTimeSpanSet(BetweenTimes(hr(9), hr(12)), BetweenTimes(hr(12.5), hr(13)), BetweenTimes(hr(14), hr(17)))
+
+	isEq(t, 0, spansString(s.Gaps(BetweenTimes(hr(9), hr(17)))), "12:00-12:30 13:00-14:00")
+	isEq(t, 0, spansString(s.Gaps(BetweenTimes(hr(10), hr(15)))), "12:00-12:30 13:00-14:00")
+	isEq(t, 0, spansString(s.Gaps(BetweenTimes(hr(16), hr(19)))), "17:00-19:00")
+	isEq(t, 0, spansString(s.Gaps(TimeSpanOf(hr(8), -2*time.Hour))), "06:00-08:00")
+	isEq(t, 0, len(s.Gaps(BetweenTimes(hr(10), hr(11)))), 0)
+	isEq(t, 0, len(s.Gaps(ZeroTimeSpan(hr(8)))), 0)
+	isEq(t, 0, spansString(TimeSpanSet{}.Gaps(BetweenTimes(hr(10), hr(11)))), "10:00-11:00")
+
+	isEq(t, 0, spansString(s.FreeSlots(BetweenTimes(hr(8), hr(18)), 45*time.Minute)), "08:00-09:00 13:00-14:00 17:00-18:00")
+	isEq(t, 0, spansString(s.FreeSlots(BetweenTimes(hr(8), hr(18)), 61*time.Minute)), "")
+
+	// the results are in the location of the window
+	gaps := s.Gaps(BetweenTimes(hr(9), hr(17)).In(london))
+	isEq(t, 0, gaps[0].Start().Location(), london)
+}
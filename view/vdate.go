@@ -37,7 +37,8 @@ func (v VDate) Date() date.Date {
 	return v.d
 }
 
-// IsYesterday returns true if the date is yesterday's date.
+// IsYesterday returns true if the date is yesterday's date. Like IsToday and IsTomorrow,
+// this uses date.Today, so the current time is given by date.SetNowFunc.
 func (v VDate) IsYesterday() bool {
 	return v.d+1 == date.Today()
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/rickb777/date/v2"
+	"github.com/rickb777/date/v2/datetest"
 )
 
 func TestBasicFormatting(t *testing.T) {
@@ -84,6 +85,26 @@ func TestIsToday(t *testing.T) {
 
 }
 
+func TestIsTodayWithFakeTime(t *testing.T) {
+	fake := datetest.NewFake(time.Date(2024, 3, 31, 12, 0, 0, 0, time.Local))
+	fake.Install(t)
+
+	v := NewVDate(date.New(2024, time.April, 1))
+	if v.IsYesterday() || v.IsToday() || !v.IsTomorrow() {
+		t.Errorf("%s should be 'tomorrow'", v)
+	}
+
+	fake.AdvanceDays(1)
+	if v.IsYesterday() || !v.IsToday() || v.IsTomorrow() {
+		t.Errorf("%s should be 'today'", v)
+	}
+
+	fake.Advance(24 * time.Hour)
+	if !v.IsYesterday() || v.IsToday() || v.IsTomorrow() {
+		t.Errorf("%s should be 'yesterday'", v)
+	}
+}
+
 func TestIsOdd(t *testing.T) {
 	d25 := date.New(2012, time.June, 25)
 